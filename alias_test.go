@@ -0,0 +1,363 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// aliasPrec is the precision of the operands of the aliasing tests
+const aliasPrec = 64
+
+// aliasOperand creates the i-th operand of the given type,
+// different operands have different values so that swapped operands are detected
+func aliasOperand(t reflect.Type, i int, square bool) reflect.Value {
+	a, b := int64(3+2*i), int64(-1-i)
+	rational := func() *Rational {
+		return NewRational(big.NewRat(a, 4), big.NewRat(b, 3))
+	}
+	float := func() *Float {
+		x := newFloat(aliasPrec)
+		x.SetRat(rational())
+		return x
+	}
+	switch t {
+	case reflect.TypeOf(&Float{}):
+		return reflect.ValueOf(float())
+	case reflect.TypeOf(&Rational{}):
+		return reflect.ValueOf(rational())
+	case reflect.TypeOf(&GaussianInt{}):
+		return reflect.ValueOf(newGaussianInt(a, b))
+	case reflect.TypeOf(&Dual{}):
+		return reflect.ValueOf(NewDual(float(), NewFloat(big.NewFloat(float64(b)/8).SetPrec(aliasPrec), big.NewFloat(.25).SetPrec(aliasPrec))))
+	case reflect.TypeOf(&HyperDual{}):
+		x := float()
+		return reflect.ValueOf(NewHyperDual(x, newFloat(aliasPrec).Sqrt(x), newFloat(aliasPrec).Exp(x), newReal(b, aliasPrec)))
+	case reflect.TypeOf(&Quaternion{}):
+		return reflect.ValueOf(quaternion(aliasPrec, float64(a)/4, float64(b)/3, .5, float64(i)-1))
+	case reflect.TypeOf(&Ball{}):
+		return reflect.ValueOf(NewBall(float(), big.NewFloat(1e-9)))
+	case reflect.TypeOf(&Matrix{}):
+		m := NewMatrix(aliasPrec)
+		m.Values = [][]Rational{{*rational()}}
+		if square {
+			m.Values = [][]Rational{
+				{*rational(), *NewRational(big.NewRat(1, 1), big.NewRat(a, 5))},
+				{*NewRational(big.NewRat(b, 7), big.NewRat(0, 1)), *NewRational(big.NewRat(2, 1), big.NewRat(1, 1))},
+			}
+		}
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&BallMatrix{}):
+		m := NewBallMatrix(aliasPrec)
+		m.SetMatrix(aliasOperand(reflect.TypeOf(&Matrix{}), i, square).Interface().(*Matrix))
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&FloatMatrix{}):
+		m := NewFloatMatrix(aliasPrec)
+		m.SetMatrix(aliasOperand(reflect.TypeOf(&Matrix{}), i, square).Interface().(*Matrix))
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&Dense[*Rational]{}):
+		m := aliasOperand(reflect.TypeOf(&Matrix{}), i, square).Interface().(*Matrix).Dense()
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&Dense[*Float]{}):
+		m := aliasOperand(reflect.TypeOf(&Matrix{}), i, square).Interface().(*Matrix).Dense()
+		d := Dense[*Float]{Field: NewFloatField(aliasPrec, big.ToNearestEven)}
+		for _, row := range m.Values {
+			var values []*Float
+			for _, x := range row {
+				y := newFloat(aliasPrec)
+				y.SetRat(x)
+				values = append(values, y)
+			}
+			d.Values = append(d.Values, values)
+		}
+		return reflect.ValueOf(&d)
+	case reflect.TypeOf(&Polynomial[*Rational]{}):
+		p := NewPolynomial[*Rational](RationalField{}, rational(), NewRational(big.NewRat(b, 5), big.NewRat(1, 1)), rational())
+		return reflect.ValueOf(&p)
+	case reflect.TypeOf(0):
+		return reflect.ValueOf(-i)
+	case reflect.TypeOf(&big.Float{}):
+		return reflect.ValueOf(big.NewFloat(float64(i) + .5))
+	}
+	return reflect.Value{}
+}
+
+// aliasReceiver creates a zero receiver of the given type
+func aliasReceiver(t reflect.Type) reflect.Value {
+	switch t {
+	case reflect.TypeOf(&Float{}):
+		return reflect.ValueOf(newFloat(aliasPrec))
+	case reflect.TypeOf(&Rational{}):
+		return reflect.ValueOf(NewRational(big.NewRat(0, 1), big.NewRat(0, 1)))
+	case reflect.TypeOf(&GaussianInt{}):
+		return reflect.ValueOf(newGaussianInt(0, 0))
+	case reflect.TypeOf(&Quaternion{}):
+		return reflect.ValueOf(newQuaternion(aliasPrec))
+	case reflect.TypeOf(&Dual{}):
+		return reflect.ValueOf(newDual(aliasPrec))
+	case reflect.TypeOf(&HyperDual{}):
+		return reflect.ValueOf(newHyperDual(aliasPrec))
+	case reflect.TypeOf(&Ball{}):
+		return reflect.ValueOf(newBall(aliasPrec))
+	case reflect.TypeOf(&Matrix{}):
+		m := NewMatrix(aliasPrec)
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&BallMatrix{}):
+		m := NewBallMatrix(aliasPrec)
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&FloatMatrix{}):
+		m := NewFloatMatrix(aliasPrec)
+		return reflect.ValueOf(&m)
+	case reflect.TypeOf(&Dense[*Rational]{}):
+		return reflect.ValueOf(&Dense[*Rational]{})
+	case reflect.TypeOf(&Dense[*Float]{}):
+		return reflect.ValueOf(&Dense[*Float]{})
+	case reflect.TypeOf(&Polynomial[*Rational]{}):
+		return reflect.ValueOf(&Polynomial[*Rational]{})
+	}
+	return reflect.Value{}
+}
+
+// aliasText formats x exactly
+func aliasText(x interface{}) string {
+	switch x := x.(type) {
+	case *Float:
+		return x.A.Text('p', 0) + " " + x.B.Text('p', 0)
+	case *Rational:
+		return x.String()
+	case *GaussianInt:
+		return x.String()
+	case *Dual:
+		return aliasText(x.A) + " " + aliasText(x.B)
+	case *HyperDual:
+		s := ""
+		for _, p := range x.parts() {
+			s += aliasText(p) + " "
+		}
+		return s
+	case *Quaternion:
+		s := ""
+		for _, p := range x.parts() {
+			s += p.Text('p', 0) + " "
+		}
+		return s
+	case *Ball:
+		return aliasText(x.M) + " " + x.R.Text('p', 0)
+	case *Matrix:
+		s := ""
+		for _, row := range x.Values {
+			for i := range row {
+				s += row[i].String() + " "
+			}
+			s += ";"
+		}
+		return s
+	case *BallMatrix:
+		s := ""
+		for _, row := range x.Values {
+			for i := range row {
+				s += aliasText(&row[i]) + " "
+			}
+			s += ";"
+		}
+		return s
+	case *FloatMatrix:
+		s := ""
+		for _, row := range x.Values {
+			for i := range row {
+				s += aliasText(&row[i]) + " "
+			}
+			s += ";"
+		}
+		return s
+	case *Dense[*Rational]:
+		return x.String()
+	case *Polynomial[*Rational]:
+		return x.String()
+	case *Dense[*Float]:
+		s := ""
+		for _, row := range x.Values {
+			for i := range row {
+				s += aliasText(row[i]) + " "
+			}
+			s += ";"
+		}
+		return s
+	}
+	return ""
+}
+
+// aliasPoison overwrites the value of x in place
+func aliasPoison(x interface{}) {
+	switch x := x.(type) {
+	case *Float:
+		x.A.SetInt64(1234567)
+		x.B.SetInt64(-7654321)
+	case *Rational:
+		x.A.SetInt64(1234567)
+		x.B.SetInt64(-7654321)
+	case *GaussianInt:
+		x.A.SetInt64(1234567)
+		x.B.SetInt64(-7654321)
+	case *Quaternion:
+		for _, p := range x.parts() {
+			p.SetInt64(1234567)
+		}
+	case *Dual:
+		aliasPoison(x.A)
+		aliasPoison(x.B)
+	case *HyperDual:
+		for _, p := range x.parts() {
+			aliasPoison(p)
+		}
+	case *Ball:
+		aliasPoison(x.M)
+		x.R.SetInt64(1234567)
+	case *Matrix:
+		for _, row := range x.Values {
+			for i := range row {
+				aliasPoison(&row[i])
+			}
+		}
+	case *BallMatrix:
+		for _, row := range x.Values {
+			for i := range row {
+				aliasPoison(&row[i])
+			}
+		}
+	case *FloatMatrix:
+		for _, row := range x.Values {
+			for i := range row {
+				aliasPoison(&row[i])
+			}
+		}
+	case *Dense[*Rational]:
+		for _, row := range x.Values {
+			for i := range row {
+				aliasPoison(row[i])
+			}
+		}
+	case *Dense[*Float]:
+		for _, row := range x.Values {
+			for i := range row {
+				aliasPoison(row[i])
+			}
+		}
+	case *Polynomial[*Rational]:
+		for _, c := range x.Coefficients {
+			aliasPoison(c)
+		}
+	}
+}
+
+// aliasCall calls the method with the receiver and arguments, returning false if it panics
+func aliasCall(method reflect.Value, receiver reflect.Value, args []reflect.Value) (result reflect.Value, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+	return method.Call(append([]reflect.Value{receiver}, args...))[0], true
+}
+
+// TestAliasing generates aliasing tests for every method of the form z.Op(x, y, ...) *T:
+// the receiver may alias any of the operands and the operands are never mutated
+// https://golang.org/pkg/math/big/
+func TestAliasing(t *testing.T) {
+	types := []reflect.Type{
+		reflect.TypeOf(&Float{}),
+		reflect.TypeOf(&Rational{}),
+		reflect.TypeOf(&Ball{}),
+		reflect.TypeOf(&Matrix{}),
+		reflect.TypeOf(&BallMatrix{}),
+		reflect.TypeOf(&FloatMatrix{}),
+		reflect.TypeOf(&GaussianInt{}),
+		reflect.TypeOf(&Quaternion{}),
+		reflect.TypeOf(&Dual{}),
+		reflect.TypeOf(&HyperDual{}),
+		reflect.TypeOf(&Dense[*Rational]{}),
+		reflect.TypeOf(&Dense[*Float]{}),
+		reflect.TypeOf(&Polynomial[*Rational]{}),
+	}
+	for _, typ := range types {
+		for m := 0; m < typ.NumMethod(); m++ {
+			method := typ.Method(m)
+			signature := method.Type
+			if signature.NumOut() != 1 || signature.Out(0) != typ {
+				continue
+			}
+			var operands []int
+			for i := 1; i < signature.NumIn(); i++ {
+				if signature.In(i) == typ {
+					operands = append(operands, i-1)
+				}
+			}
+			if len(operands) == 0 {
+				continue
+			}
+
+			for _, square := range []bool{false, true} {
+				name := typ.Elem().Name() + "." + method.Name
+				args := func() []reflect.Value {
+					var args []reflect.Value
+					for i := 1; i < signature.NumIn(); i++ {
+						args = append(args, aliasOperand(signature.In(i), i-1, square))
+					}
+					return args
+				}
+
+				// the operands are not mutated by the method or by later changes to the result
+				x, y := args(), args()
+				result, ok := aliasCall(method.Func, aliasReceiver(typ), x)
+				if !ok {
+					continue
+				}
+				expected := aliasText(result.Interface())
+				aliasPoison(result.Interface())
+				for i := range x {
+					if !reflect.DeepEqual(aliasText(x[i].Interface()), aliasText(y[i].Interface())) {
+						t.Fatal("operand mutated", name, i)
+					}
+				}
+
+				// the receiver aliases one of the operands
+				for _, i := range operands {
+					x := args()
+					result, ok := aliasCall(method.Func, x[i], x)
+					if !ok {
+						t.Fatal("panic", name, i)
+					}
+					if result.Pointer() != x[i].Pointer() {
+						t.Fatal("result is not the receiver", name)
+					}
+					if s := aliasText(result.Interface()); s != expected {
+						t.Fatal("invalid aliased result", name, i, s, expected)
+					}
+				}
+
+				// the receiver aliases every operand
+				x, y = args(), args()
+				for _, i := range operands {
+					x[i] = x[operands[0]]
+					y[i] = aliasOperand(typ, operands[0], square)
+				}
+				result, ok = aliasCall(method.Func, aliasReceiver(typ), y)
+				if !ok {
+					continue
+				}
+				expected = aliasText(result.Interface())
+				result, ok = aliasCall(method.Func, x[operands[0]], x)
+				if !ok {
+					t.Fatal("panic", name)
+				}
+				if s := aliasText(result.Interface()); s != expected {
+					t.Fatal("invalid aliased result", name, s, expected)
+				}
+			}
+		}
+	}
+}
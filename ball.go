@@ -0,0 +1,811 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"sync"
+)
+
+// ballGuard is the number of guard bits used by the ball functions
+const ballGuard = 32
+
+// radPrec is the precision of the radius of a ball
+const radPrec = 32
+
+var (
+	ballPiMutex sync.Mutex
+	ballPiValue *Ball
+)
+
+// Ball is a complex ball, the disk of numbers within the radius R of the midpoint M.
+// The result of every function contains the results for every number in the arguments,
+// so the radius is a rigorous bound of the error
+// https://arblib.org/
+type Ball struct {
+	M *Float
+	R *big.Float
+}
+
+// NewBall creates a new ball, a nil radius is zero
+func NewBall(m *Float, r *big.Float) *Ball {
+	rad := newRad()
+	if r != nil {
+		rad.Abs(r)
+	}
+	return &Ball{
+		M: m,
+		R: rad,
+	}
+}
+
+// newBall creates an exact zero ball with the given precision
+func newBall(prec uint) *Ball {
+	return NewBall(newFloat(prec), nil)
+}
+
+// exact copies x without rounding
+func exact(x *Float) *Float {
+	return NewFloat(big.NewFloat(0).SetPrec(x.A.Prec()).Set(x.A), big.NewFloat(0).SetPrec(x.B.Prec()).Set(x.B))
+}
+
+// exactBall creates an exact ball from a copy of x
+func exactBall(x *Float) *Ball {
+	return NewBall(exact(x), nil)
+}
+
+// infBall creates a ball that contains every number
+func infBall(prec uint) *Ball {
+	b := newBall(prec)
+	b.R.SetInf(false)
+	return b
+}
+
+// newRad creates a zero radius that is rounded up
+func newRad() *big.Float {
+	return big.NewFloat(0).SetPrec(radPrec).SetMode(big.ToPositiveInf)
+}
+
+// newRadLower creates a zero lower bound that is rounded down
+func newRadLower() *big.Float {
+	return big.NewFloat(0).SetPrec(radPrec).SetMode(big.ToNegativeInf)
+}
+
+// radUlp computes a bound of the rounding error of the last operation on x
+func radUlp(x *big.Float) *big.Float {
+	r := newRad()
+	if x.Acc() == big.Exact || x.Sign() == 0 || x.IsInf() {
+		return r
+	}
+	return r.SetMantExp(big.NewFloat(1), x.MantExp(nil)-int(x.Prec()))
+}
+
+// radErr computes a bound of the rounding error of the last operations on both parts of x
+func radErr(x *Float) *big.Float {
+	r := radUlp(x.A)
+	return r.Add(r, radUlp(x.B))
+}
+
+// radHypot computes an upper bound of sqrt(a^2 + b^2) for upper bounds a and b,
+// with a margin for the rounding of Sqrt
+func radHypot(a, b *big.Float) *big.Float {
+	r, t := newRad().Mul(a, a), newRad().Mul(b, b)
+	r.Add(r, t)
+	return radSqrt(r)
+}
+
+// radSqrt computes an upper bound of sqrt(x) with a margin for the rounding of Sqrt
+func radSqrt(x *big.Float) *big.Float {
+	r := newRad().Sqrt(x)
+	return r.Mul(r, newRad().SetFloat64(1+1.0/(1<<(radPrec-4))))
+}
+
+// radMag computes an upper bound of |x|
+func radMag(x *Float) *big.Float {
+	return radHypot(newRad().Abs(x.A), newRad().Abs(x.B))
+}
+
+// radMagLower computes a lower bound of |x|
+func radMagLower(x *Float) *big.Float {
+	a, b := newRadLower().Abs(x.A), newRadLower().Abs(x.B)
+	a.Mul(a, a)
+	b.Mul(b, b)
+	a.Add(a, b)
+	a.Sqrt(a)
+	return a.Mul(a, newRadLower().SetFloat64(1-1.0/(1<<(radPrec-4))))
+}
+
+// radRat computes an upper bound of |x|
+func radRat(x *Rational) *big.Float {
+	a, b := big.NewRat(0, 1).Abs(x.A), big.NewRat(0, 1).Abs(x.B)
+	return radHypot(newRad().SetRat(a), newRad().SetRat(b))
+}
+
+// radExp computes an upper bound of e^x
+func radExp(x *big.Float) *big.Float {
+	v, acc := x.Float64()
+	if acc == big.Below {
+		v = math.Nextafter(v, math.Inf(1))
+	}
+	e := math.Nextafter(math.Nextafter(math.Exp(v), math.Inf(1)), math.Inf(1))
+	return newRad().SetFloat64(e)
+}
+
+// rational converts x into an exact rational
+func rational(x *Float) *Rational {
+	r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+	x.Rat(r)
+	return r
+}
+
+// ballRat creates a ball with the given precision that contains every rational within r of x
+// https://en.wikipedia.org/wiki/Ball_arithmetic
+func ballRat(x, r *big.Rat, prec uint) *Ball {
+	b := newBall(prec)
+	b.M.A.SetRat(x)
+	d, _ := b.M.A.Rat(nil)
+	d.Sub(d, x)
+	d.Abs(d)
+	d.Add(d, r)
+	b.R.SetRat(d)
+	return b
+}
+
+// ballPi computes pi = 16 atan(1/5) - 4 atan(1/239) with the given precision, only the value
+// with the highest precision so far is cached and it is rounded down for a lower precision
+// https://en.wikipedia.org/wiki/Machin-like_formula
+func ballPi(prec uint) *Ball {
+	ballPiMutex.Lock()
+	defer ballPiMutex.Unlock()
+	if pi := ballPiValue; pi != nil && pi.prec() >= prec {
+		if pi.prec() == prec {
+			return NewBall(pi.M.clone(prec), pi.R)
+		}
+		return newBall(prec).set(pi.M, pi.R)
+	}
+
+	eps := big.NewRat(0, 1).SetFrac(big.NewInt(1), big.NewInt(0).Lsh(big.NewInt(1), prec+8))
+	sum, tail := big.NewRat(0, 1), big.NewRat(0, 1)
+	for _, c := range []struct{ k, c int64 }{{5, 16}, {239, -4}} {
+		kk, power := big.NewInt(c.k*c.k), big.NewInt(c.k)
+		for j := int64(0); ; j++ {
+			// the series alternates with decreasing terms so the error is less than the next term
+			d := big.NewInt(0).Mul(power, big.NewInt(2*j+1))
+			term := big.NewRat(0, 1).SetFrac(big.NewInt(c.c), d)
+			if big.NewRat(0, 1).Abs(term).Cmp(eps) < 0 {
+				tail.Add(tail, term.Abs(term))
+				break
+			}
+			if j%2 == 1 {
+				term.Neg(term)
+			}
+			sum.Add(sum, term)
+			power.Mul(power, kk)
+		}
+	}
+	pi := ballRat(sum, tail, prec)
+	ballPiValue = pi
+	return NewBall(pi.M.clone(prec), pi.R)
+}
+
+// prec returns the precision of the midpoint of b
+func (b *Ball) prec() uint {
+	return b.M.A.Prec()
+}
+
+// set rounds the midpoint x into b with the radius r plus the rounding error
+func (b *Ball) set(x *Float, r *big.Float) *Ball {
+	rad := newRad().Set(r)
+	if x.IsNaN() || x.IsInf() {
+		rad.SetInf(false)
+	}
+	if !rad.IsInf() {
+		b.M.A.Set(x.A)
+		rad.Add(rad, radUlp(b.M.A))
+		b.M.B.Set(x.B)
+		rad.Add(rad, radUlp(b.M.B))
+	} else {
+		b.M.A.SetInt64(0)
+		b.M.B.SetInt64(0)
+	}
+	b.R.SetPrec(radPrec).SetMode(big.ToPositiveInf).Set(rad)
+	return b
+}
+
+// mag computes an upper bound of the absolute value of every number in b
+func (b *Ball) mag() *big.Float {
+	m := radMag(b.M)
+	return m.Add(m, b.R)
+}
+
+// quo divides x by the integer n
+func (b *Ball) quo(x *Ball, n int64) *Ball {
+	d := big.NewFloat(float64(n))
+	m := newFloat(b.prec())
+	m.A.Quo(x.M.A, d)
+	m.B.Quo(x.M.B, d)
+	r := newRad().Quo(x.R, newRadLower().Abs(d))
+	return b.set(m, r.Add(r, radErr(m)))
+}
+
+// scale multiplies x by 2^n exactly
+func (b *Ball) scale(x *Ball, n int) *Ball {
+	m := x.clone()
+	m.M.A.SetMantExp(m.M.A, n)
+	m.M.B.SetMantExp(m.M.B, n)
+	m.R.SetMantExp(m.R, n)
+	return b.set(m.M, m.R)
+}
+
+// clone copies b
+func (b *Ball) clone() *Ball {
+	return NewBall(exact(b.M), b.R)
+}
+
+// Add adds two balls
+func (b *Ball) Add(x, y *Ball) *Ball {
+	m := newFloat(b.prec())
+	m.Add(x.M, y.M)
+	r := newRad().Add(x.R, y.R)
+	return b.set(m, r.Add(r, radErr(m)))
+}
+
+// Sub subtracts two balls
+func (b *Ball) Sub(x, y *Ball) *Ball {
+	m := newFloat(b.prec())
+	m.Sub(x.M, y.M)
+	r := newRad().Add(x.R, y.R)
+	return b.set(m, r.Add(r, radErr(m)))
+}
+
+// Neg negates a ball
+func (b *Ball) Neg(x *Ball) *Ball {
+	m := exact(x.M)
+	m.A.Neg(m.A)
+	m.B.Neg(m.B)
+	return b.set(m, x.R)
+}
+
+// Conj computes the complex conjugate of a ball
+func (b *Ball) Conj(x *Ball) *Ball {
+	m := exact(x.M)
+	m.B.Neg(m.B)
+	return b.set(m, x.R)
+}
+
+// Mul multiplies two balls
+// |xy - x'y'| <= |x'|r_y + |y'|r_x + r_x r_y
+func (b *Ball) Mul(x, y *Ball) *Ball {
+	if x.R.IsInf() || y.R.IsInf() {
+		return b.set(b.M, newRad().SetInf(false))
+	}
+	m := newFloat(b.prec())
+	m.Mul(x.M, y.M)
+	r := newRad().Mul(radMag(x.M), y.R)
+	t := newRad().Mul(radMag(y.M), x.R)
+	r.Add(r, t)
+	t.Mul(x.R, y.R)
+	r.Add(r, t)
+	return b.set(m, r.Add(r, radErr(m)))
+}
+
+// ballInv computes 1/y with the given precision
+// the error of the midpoint c is bounded with the residual e = cy' - 1, 1/y' = c/(1 + e)
+// and |1/y - 1/y'| <= r/(|y'|(|y'| - r))
+func ballInv(y *Ball, prec uint) *Ball {
+	l := radMagLower(y.M)
+	if y.R.IsInf() || l.Cmp(y.R) <= 0 {
+		return infBall(prec)
+	}
+	c := newFloat(prec).inv(y.M)
+	e := NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Mul(rational(c), rational(y.M))
+	e.A.Sub(e.A, big.NewRat(1, 1))
+	eps := radRat(e)
+	d := newRadLower().Sub(newRadLower().SetInt64(1), eps)
+	if d.Sign() <= 0 {
+		return infBall(prec)
+	}
+	r := newRad().Mul(radMag(c), eps)
+	r.Quo(r, d)
+	t := newRadLower().Sub(l, y.R)
+	t.Mul(t, l)
+	r.Add(r, newRad().Quo(y.R, t))
+	return NewBall(c, r)
+}
+
+// Div divides two balls
+func (b *Ball) Div(x, y *Ball) *Ball {
+	return b.Mul(x, ballInv(y, b.prec()+ballGuard))
+}
+
+// ballExp computes e^x with the given precision by reducing the imaginary part modulo
+// 2pi and halving x k times until |x| <= 1/2, then squaring the Taylor series k times
+// https://en.wikipedia.org/wiki/Exponential_function#Computation
+func ballExp(x *Ball, prec uint) *Ball {
+	if x.R.IsInf() {
+		return infBall(prec)
+	}
+
+	z := x.clone()
+	if e := x.M.B.MantExp(nil); e > 2 {
+		pi := ballPi(prec + uint(e) + 2)
+		q := big.NewFloat(0).SetPrec(uint(e)+64).Quo(x.M.B, pi.M.A)
+		q.Quo(q, big.NewFloat(2))
+		n, _ := q.Int(nil)
+		t := NewFloat(big.NewFloat(0).SetPrec(pi.prec()).SetInt(n), big.NewFloat(0).SetPrec(pi.prec()))
+		pi.Mul(pi, exactBall(t))
+		pi.Mul(pi, exactBall(NewFloat(big.NewFloat(0), big.NewFloat(2))))
+		z = NewBall(newFloat(pi.prec()), nil).Sub(x, pi)
+	}
+	k := 0
+	if m := z.mag(); m.IsInf() {
+		return infBall(prec)
+	} else if m.Cmp(big.NewFloat(.5)) > 0 {
+		k = m.MantExp(nil) + 1
+	}
+
+	wp := prec + uint(k) + ballGuard
+	t := newBall(wp).scale(z, -k)
+	eps := newRad().SetMantExp(big.NewFloat(1), -int(wp))
+	sum, term := newBall(wp), newBall(wp)
+	sum.M.A.SetInt64(1)
+	term.M.A.SetInt64(1)
+	for j := int64(1); ; j++ {
+		term.Mul(term, t)
+		term.quo(term, j)
+		if m := term.mag(); m.Cmp(eps) <= 0 {
+			// the tail is less than a geometric series with ratio 1/2
+			sum.R.Add(sum.R, m.Add(m, m))
+			break
+		}
+		sum.Add(sum, term)
+	}
+	for i := 0; i < k; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum
+}
+
+// Exp computes e^x for a ball
+func (b *Ball) Exp(x *Ball) *Ball {
+	y := ballExp(x, b.prec()+ballGuard)
+	return b.set(y.M, y.R)
+}
+
+// crossesCut determines if x is within r of the branch cut on the negative real axis
+func crossesCut(x *Float, r *big.Float) bool {
+	return r.Sign() > 0 && x.A.Sign() <= 0 && newRadLower().Abs(x.B).Cmp(r) <= 0
+}
+
+// ballLog computes log(x) with the given precision
+// with the approximation l = log(x'), log(x') = l + log(w) where w = x'e^-l is close to one,
+// and |log(x) - log(x')| <= r/(|x'| - r) plus 2pi across the branch cut
+// https://en.wikipedia.org/wiki/Logarithm#Power_series
+func ballLog(x *Ball, prec uint) *Ball {
+	l := radMagLower(x.M)
+	if x.R.IsInf() || l.Cmp(x.R) <= 0 {
+		return infBall(prec)
+	}
+
+	l0 := log(x.M, prec)
+	n := l0.clone(prec)
+	n.A.Neg(n.A)
+	n.B.Neg(n.B)
+	w := newBall(prec).Mul(exactBall(x.M), ballExp(exactBall(n), prec))
+	u := newBall(prec).Sub(w, exactBall(NewFloat(big.NewFloat(1), big.NewFloat(0))))
+	if u.mag().Cmp(big.NewFloat(.5)) > 0 {
+		return infBall(prec)
+	}
+
+	// log(1 + u) = u - u^2/2 + u^3/3 - ...
+	eps := newRad().SetMantExp(big.NewFloat(1), -int(prec))
+	sum, power, term := u.clone(), u.clone(), newBall(prec)
+	for j := int64(2); ; j++ {
+		power.Mul(power, u)
+		term.quo(power, j)
+		if m := term.mag(); m.Cmp(eps) <= 0 {
+			// the tail is less than a geometric series with ratio 1/2
+			sum.R.Add(sum.R, m.Add(m, m))
+			break
+		}
+		if j%2 == 0 {
+			sum.Sub(sum, term)
+		} else {
+			sum.Add(sum, term)
+		}
+	}
+	sum.Add(sum, exactBall(l0))
+
+	t := newRadLower().Sub(l, x.R)
+	sum.R.Add(sum.R, newRad().Quo(x.R, t))
+	if crossesCut(x.M, x.R) {
+		// 2pi < 7
+		sum.R.Add(sum.R, big.NewFloat(7))
+	}
+	return sum
+}
+
+// Log computes the natural log of a ball
+func (b *Ball) Log(x *Ball) *Ball {
+	y := ballLog(x, b.prec()+ballGuard)
+	return b.set(y.M, y.R)
+}
+
+// ballSinCos computes sin(x) = (e^(ix) - e^(-ix))/2i and cos(x) = (e^(ix) + e^(-ix))/2
+// with the given precision, and |sin(x) - sin(x')| <= r cosh(|Im x'| + r) <= r e^(|Im x'| + r)
+func ballSinCos(x *Ball, prec uint) (sin, cos *Ball) {
+	if x.R.IsInf() {
+		return infBall(prec), infBall(prec)
+	}
+	iz := NewFloat(big.NewFloat(0).SetPrec(x.M.B.Prec()).Neg(x.M.B), big.NewFloat(0).SetPrec(x.M.A.Prec()).Set(x.M.A))
+	e1 := ballExp(exactBall(iz), prec)
+	iz.A.Neg(iz.A)
+	iz.B.Neg(iz.B)
+	e2 := ballExp(exactBall(iz), prec)
+
+	d := newBall(prec).Sub(e1, e2)
+	sin = NewBall(NewFloat(d.M.B, d.M.A.Neg(d.M.A)), d.R)
+	sin.scale(sin, -1)
+	cos = newBall(prec).Add(e1, e2)
+	cos.scale(cos, -1)
+
+	if x.R.Sign() != 0 {
+		y := newRad().Abs(x.M.B)
+		y.Add(y, x.R)
+		r := radExp(y)
+		r.Mul(r, x.R)
+		sin.R.Add(sin.R, r)
+		cos.R.Add(cos.R, r)
+	}
+	return sin, cos
+}
+
+// Sin computes the sine of a ball
+func (b *Ball) Sin(x *Ball) *Ball {
+	y, _ := ballSinCos(x, b.prec()+ballGuard)
+	return b.set(y.M, y.R)
+}
+
+// Cos computes the cosine of a ball
+func (b *Ball) Cos(x *Ball) *Ball {
+	_, y := ballSinCos(x, b.prec()+ballGuard)
+	return b.set(y.M, y.R)
+}
+
+// Tan computes the tangent of a ball
+func (b *Ball) Tan(x *Ball) *Ball {
+	prec := b.prec() + ballGuard
+	sin, cos := ballSinCos(x, prec)
+	y := newBall(prec).Mul(sin, ballInv(cos, prec))
+	return b.set(y.M, y.R)
+}
+
+// ballSqrt computes sqrt(x) with the given precision
+// with the approximation c = sqrt(x') and the residual d = c^2 - x',
+// |sqrt(x) - c| <= (r + |d|)/(2 sqrt(|x'| - r - |d|)) away from the branch cut
+func ballSqrt(x *Ball, prec uint) *Ball {
+	if x.R.IsInf() {
+		return infBall(prec)
+	}
+	m := x.M
+	if m.B.Sign() == 0 && m.A.Sign() < 0 && x.R.Sign() == 0 {
+		// sqrt(-a) = i sqrt(a)
+		y := ballSqrt(NewBall(NewFloat(big.NewFloat(0).SetPrec(m.A.Prec()).Neg(m.A), big.NewFloat(0)), nil), prec)
+		y.M.A, y.M.B = y.M.B, y.M.A
+		return y
+	}
+
+	c := sqrt(m, prec)
+	d := NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Mul(rational(c), rational(c))
+	d.Sub(d, rational(m))
+	s := newRad().Add(x.R, radRat(d))
+	if s.Sign() == 0 {
+		return NewBall(c, nil)
+	}
+	l := radMagLower(m)
+	if c.A.Sign() > 0 && l.Cmp(s) > 0 && !crossesCut(m, s) {
+		t := newRadLower().Sub(l, s)
+		t.Sqrt(t)
+		t.Mul(t, newRadLower().SetFloat64(2*(1-1.0/(1<<(radPrec-4)))))
+		return NewBall(c, newRad().Quo(s, t))
+	}
+
+	// every square root is within sqrt(|x'| + r) of zero
+	r := radMag(m)
+	r.Add(r, x.R)
+	return NewBall(newFloat(prec), radSqrt(r))
+}
+
+// Sqrt computes the square root of a ball
+func (b *Ball) Sqrt(x *Ball) *Ball {
+	y := ballSqrt(x, b.prec()+ballGuard)
+	return b.set(y.M, y.R)
+}
+
+// Pow computes x**y = e^(y log(x)) for balls
+func (b *Ball) Pow(x, y *Ball) *Ball {
+	prec := b.prec() + ballGuard
+	if x.M.A.Sign() == 0 && x.M.B.Sign() == 0 && x.R.Sign() == 0 && y.R.Sign() == 0 && y.M.A.Sign() > 0 {
+		return b.set(newFloat(prec), newRad())
+	}
+	l := ballLog(x, prec)
+	l.Mul(l, y)
+	z := ballExp(l, prec)
+	return b.set(z.M, z.R)
+}
+
+// Abs computes the absolute value of a ball
+// with the approximation c = |x'| and the residual d = c^2 - |x'|^2, ||x'| - c| <= |d|/c
+func (b *Ball) Abs(x *Ball) *Ball {
+	prec := b.prec() + ballGuard
+	c := NewFloat(modulus(x.M, prec), big.NewFloat(0).SetPrec(prec))
+	r := newRad().Set(x.R)
+	if c.A.Sign() != 0 && !x.R.IsInf() {
+		m := rational(x.M)
+		n := big.NewRat(0, 1).Mul(m.A, m.A)
+		n.Add(n, big.NewRat(0, 1).Mul(m.B, m.B))
+		a, _ := c.A.Rat(nil)
+		d := big.NewRat(0, 1).Mul(a, a)
+		d.Sub(d, n)
+		e := newRad().SetRat(d.Abs(d))
+		r.Add(r, e.Quo(e, newRadLower().Set(c.A)))
+	}
+	return b.set(c, r)
+}
+
+// Atan2 computes the principal argument of a ball, the imaginary part of its log
+func (b *Ball) Atan2(x *Ball) *Ball {
+	y := ballLog(x, b.prec()+ballGuard)
+	return b.set(NewFloat(y.M.B, big.NewFloat(0)), y.R)
+}
+
+// Contains determines if x is in the ball b
+func (b *Ball) Contains(x *Float) bool {
+	return b.containsRat(rational(x))
+}
+
+// containsRat determines if the rational x is in the ball b
+func (b *Ball) containsRat(x *Rational) bool {
+	if b.R.IsInf() {
+		return true
+	}
+	d := NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Sub(x, rational(b.M))
+	n := big.NewRat(0, 1).Mul(d.A, d.A)
+	n.Add(n, big.NewRat(0, 1).Mul(d.B, d.B))
+	r, _ := b.R.Rat(nil)
+	return n.Cmp(r.Mul(r, r)) <= 0
+}
+
+// String returns a string of the ball
+func (b *Ball) String() string {
+	return "(" + b.M.String() + ") +/- " + b.R.Text('g', 3)
+}
+
+// SetRat sets b to the ball around the rational x rounded to the precision of b
+func (b *Ball) SetRat(x *Rational) *Ball {
+	b.M.SetRat(x)
+	d := rational(b.M)
+	d.Sub(d, x)
+	b.R.SetPrec(radPrec).SetMode(big.ToPositiveInf).Set(radRat(d))
+	return b
+}
+
+// BallMatrix is a matrix of balls, the interval mode of Matrix
+type BallMatrix struct {
+	Prec   uint
+	Values [][]Ball
+}
+
+// NewBallMatrix make a new ball matrix
+func NewBallMatrix(prec uint) BallMatrix {
+	return BallMatrix{
+		Prec: prec,
+	}
+}
+
+// SetMatrix sets m to the balls around the entries of a
+func (m *BallMatrix) SetMatrix(a *Matrix) *BallMatrix {
+	values := [][]Ball{}
+	for _, a := range a.Values {
+		var row []Ball
+		for i := range a {
+			row = append(row, *newBall(m.Prec).SetRat(&a[i]))
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// singular determines if m is 1x1
+func (m *BallMatrix) singular() bool {
+	return len(m.Values) == 1 && len(m.Values[0]) == 1
+}
+
+// elementwise applies the function to the entries of a and b,
+// a 1x1 matrix is applied to every entry of the other matrix
+func (m *BallMatrix) elementwise(a, b *BallMatrix, function func(x, y *Ball) *Ball) *BallMatrix {
+	rows, columns := a, a
+	if a.singular() {
+		rows, columns = b, b
+	}
+	entry := func(x *BallMatrix, i, j int) *Ball {
+		if x.singular() {
+			return &x.Values[0][0]
+		}
+		return &x.Values[i][j]
+	}
+	values := [][]Ball{}
+	for i := range rows.Values {
+		var row []Ball
+		for j := range columns.Values[i] {
+			row = append(row, *function(entry(a, i, j), entry(b, i, j)))
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// Add adds two ball matricies
+func (m *BallMatrix) Add(a, b *BallMatrix) *BallMatrix {
+	return m.elementwise(a, b, func(x, y *Ball) *Ball {
+		return newBall(m.Prec).Add(x, y)
+	})
+}
+
+// Sub subtracts two ball matricies
+func (m *BallMatrix) Sub(a, b *BallMatrix) *BallMatrix {
+	return m.elementwise(a, b, func(x, y *Ball) *Ball {
+		return newBall(m.Prec).Sub(x, y)
+	})
+}
+
+// Mul multiplies two ball matricies
+func (m *BallMatrix) Mul(a, b *BallMatrix) *BallMatrix {
+	if a.singular() || b.singular() {
+		return m.elementwise(a, b, func(x, y *Ball) *Ball {
+			return newBall(m.Prec).Mul(x, y)
+		})
+	}
+
+	values := [][]Ball{}
+	for x := 0; x < len(a.Values); x++ {
+		var row []Ball
+		for y := 0; y < len(b.Values[0]); y++ {
+			sum := newBall(m.Prec)
+			for z := 0; z < len(b.Values); z++ {
+				ab := newBall(m.Prec)
+				ab.Mul(&a.Values[x][z], &b.Values[z][y])
+				sum.Add(sum, ab)
+			}
+			row = append(row, *sum)
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// Div divides two ball matricies
+func (m *BallMatrix) Div(a, b *BallMatrix) *BallMatrix {
+	if a.singular() && b.singular() {
+		return m.elementwise(a, b, func(x, y *Ball) *Ball {
+			return newBall(m.Prec).Div(x, y)
+		})
+	}
+
+	panic("can't divide non 1x1 matrices")
+}
+
+func (m *BallMatrix) apply(a *BallMatrix, function func(b, x *Ball) *Ball) *BallMatrix {
+	values := [][]Ball{}
+	for _, a := range a.Values {
+		var row []Ball
+		for i := range a {
+			row = append(row, *function(newBall(m.Prec), &a[i]))
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// Abs computes the absolute value of the entries of the matrix
+func (m *BallMatrix) Abs(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Abs)
+}
+
+// Conj computes the complex conjugate of a
+func (m *BallMatrix) Conj(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Conj)
+}
+
+// Sqrt computes the square root of the matrix
+func (m *BallMatrix) Sqrt(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Sqrt)
+}
+
+// Atan2 computes atan2 of the entries of the matrix
+func (m *BallMatrix) Atan2(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Atan2)
+}
+
+// Exp computes e^x for the entries of the matrix
+func (m *BallMatrix) Exp(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Exp)
+}
+
+// Cos computes the cosine of the entries of the matrix
+func (m *BallMatrix) Cos(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Cos)
+}
+
+// Sin computes the sine of the entries of the matrix
+func (m *BallMatrix) Sin(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Sin)
+}
+
+// Tan computes the tangent of the entries of the matrix
+func (m *BallMatrix) Tan(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Tan)
+}
+
+// Log computes the natural log of the entries of the matrix
+func (m *BallMatrix) Log(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Log)
+}
+
+// Pow computes x**y for the entries of the matrix
+func (m *BallMatrix) Pow(x *BallMatrix, y *Ball) *BallMatrix {
+	return m.apply(x, func(b, x *Ball) *Ball {
+		return b.Pow(x, y)
+	})
+}
+
+// Neg negates the entries of the matrix
+func (m *BallMatrix) Neg(a *BallMatrix) *BallMatrix {
+	return m.apply(a, (*Ball).Neg)
+}
+
+// Contains determines if every entry of a is in the matching ball of m
+func (m *BallMatrix) Contains(a *Matrix) bool {
+	if len(m.Values) != len(a.Values) {
+		return false
+	}
+	for i, row := range a.Values {
+		if len(m.Values[i]) != len(row) {
+			return false
+		}
+		for j := range row {
+			if !m.Values[i][j].containsRat(&row[j]) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func (m *BallMatrix) String() string {
+	if m.singular() {
+		return m.Values[0][0].String()
+	}
+
+	s, last := "[", len(m.Values)-1
+	for i, row := range m.Values {
+		lastColumn := len(row) - 1
+		for j := range row {
+			s += row[j].String()
+			if j < lastColumn {
+				s += " "
+			}
+		}
+		if i < last {
+			s += ";"
+		}
+	}
+	return s + "]"
+}
@@ -0,0 +1,167 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// ballFunctions are the ball functions and the matching float functions
+var ballFunctions = map[string]struct {
+	ball  func(b, x, y *Ball) *Ball
+	float func(f, x, y *Float) *Float
+}{
+	"Add":   {func(b, x, y *Ball) *Ball { return b.Add(x, y) }, func(f, x, y *Float) *Float { return f.Add(x, y) }},
+	"Sub":   {func(b, x, y *Ball) *Ball { return b.Sub(x, y) }, func(f, x, y *Float) *Float { return f.Sub(x, y) }},
+	"Mul":   {func(b, x, y *Ball) *Ball { return b.Mul(x, y) }, func(f, x, y *Float) *Float { return f.Mul(x, y) }},
+	"Div":   {func(b, x, y *Ball) *Ball { return b.Div(x, y) }, func(f, x, y *Float) *Float { return f.Div(x, y) }},
+	"Sqrt":  {func(b, x, y *Ball) *Ball { return b.Sqrt(x) }, func(f, x, y *Float) *Float { return f.Sqrt(x) }},
+	"Exp":   {func(b, x, y *Ball) *Ball { return b.Exp(x) }, func(f, x, y *Float) *Float { return f.Exp(x) }},
+	"Log":   {func(b, x, y *Ball) *Ball { return b.Log(x) }, func(f, x, y *Float) *Float { return f.Log(x) }},
+	"Sin":   {func(b, x, y *Ball) *Ball { return b.Sin(x) }, func(f, x, y *Float) *Float { return f.Sin(x) }},
+	"Cos":   {func(b, x, y *Ball) *Ball { return b.Cos(x) }, func(f, x, y *Float) *Float { return f.Cos(x) }},
+	"Tan":   {func(b, x, y *Ball) *Ball { return b.Tan(x) }, func(f, x, y *Float) *Float { return f.Tan(x) }},
+	"Pow":   {func(b, x, y *Ball) *Ball { return b.Pow(x, y) }, func(f, x, y *Float) *Float { return f.Pow(x, y) }},
+	"Abs":   {func(b, x, y *Ball) *Ball { return b.Abs(x) }, func(f, x, y *Float) *Float { return f.Abs(x) }},
+	"Atan2": {func(b, x, y *Ball) *Ball { return b.Atan2(x) }, func(f, x, y *Float) *Float { f.A.Set(f.Atan2(x).A); return f }},
+}
+
+func TestBall_Contains(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func(prec uint) *Float {
+		scale := float64(int(1) << uint(rng.Intn(5)))
+		return NewFloat(big.NewFloat((2*rng.Float64()-1)*scale).SetPrec(prec),
+			big.NewFloat((2*rng.Float64()-1)*scale).SetPrec(prec))
+	}
+	for i := 0; i < 32; i++ {
+		prec := uint(32 + rng.Intn(200))
+		x, y := random(prec), random(prec)
+		for name, function := range ballFunctions {
+			b := function.ball(newBall(prec), NewBall(x, nil), NewBall(y, nil))
+			f := function.float(newFloat(512), x, y)
+			if !b.Contains(f) {
+				t.Fatal("invalid enclosure", name, x.String(), y.String(), b.String(), f.String())
+			}
+			if b.R.IsInf() || (b.R.Sign() != 0 && b.R.MantExp(nil) > radMag(b.M).MantExp(nil)-int(prec)+12) {
+				t.Fatal("invalid radius", name, x.String(), y.String(), b.String())
+			}
+		}
+	}
+}
+
+func TestBall_Radius(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	r := big.NewFloat(1e-6)
+	for i := 0; i < 8; i++ {
+		x := NewFloat(big.NewFloat(4*rng.Float64()-2).SetPrec(64), big.NewFloat(4*rng.Float64()-2).SetPrec(64))
+		y := NewFloat(big.NewFloat(4*rng.Float64()-2).SetPrec(64), big.NewFloat(4*rng.Float64()-2).SetPrec(64))
+		for name, function := range ballFunctions {
+			b := function.ball(newBall(64), NewBall(x, r), NewBall(y, r))
+			for j := 0; j < 4; j++ {
+				// a point within r of the midpoint
+				d := NewFloat(big.NewFloat((2*rng.Float64()-1)*7e-7), big.NewFloat((2*rng.Float64()-1)*7e-7))
+				xx, yy := newFloat(128).Add(x, d), newFloat(128).Sub(y, d)
+				f := function.float(newFloat(512), xx, yy)
+				if !b.Contains(f) {
+					t.Fatal("invalid enclosure", name, xx.String(), yy.String(), b.String(), f.String())
+				}
+			}
+		}
+	}
+}
+
+func TestBall_Inf(t *testing.T) {
+	zero := NewBall(newFloat(64), big.NewFloat(.5))
+	one := NewBall(NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64)), nil)
+	if b := newBall(64).Div(one, zero); !b.R.IsInf() {
+		t.Fatal("invalid result", b.String())
+	}
+	if b := newBall(64).Log(zero); !b.R.IsInf() {
+		t.Fatal("invalid result", b.String())
+	}
+	if b := newBall(64).Add(one, newBall(64).Log(zero)); !b.R.IsInf() {
+		t.Fatal("invalid result", b.String())
+	}
+	if b := newBall(64).Sqrt(zero); b.R.IsInf() || !b.Contains(NewFloat(big.NewFloat(0), big.NewFloat(.7))) {
+		t.Fatal("invalid result", b.String())
+	}
+}
+
+func TestBall_String(t *testing.T) {
+	a := NewBall(NewFloat(big.NewFloat(3).SetPrec(64), big.NewFloat(1e6).SetPrec(64)), nil)
+	a.Exp(a)
+	t.Log(a.String())
+	if a.String() != "(18.81516945 + -7.029807411i) +/- 2.17e-18" {
+		t.Fatal("invalid result")
+	}
+
+	a = ballPi(100)
+	t.Log(a.String())
+	if a.M.A.Text('g', 30) != "3.14159265358979323846264338328" {
+		t.Fatal("invalid result")
+	}
+
+	// a lower precision is rounded down from the cached value with the highest precision
+	b := ballPi(300)
+	a = ballPi(100)
+	if ballPiValue.prec() < 300 || a.prec() != 100 || a.M.A.Text('g', 30) != "3.14159265358979323846264338328" {
+		t.Fatal("invalid result", a.String())
+	}
+	if a.R.Cmp(big.NewFloat(0x1p-97)) > 0 || !a.Contains(b.M) {
+		t.Fatal("invalid radius", a.String())
+	}
+}
+
+func TestBallMatrix(t *testing.T) {
+	a := NewMatrix(64)
+	a.Values = [][]Rational{
+		{*NewRational(big.NewRat(1, 3), big.NewRat(0, 1)), *NewRational(big.NewRat(2, 1), big.NewRat(1, 7))},
+		{*NewRational(big.NewRat(0, 1), big.NewRat(-1, 1)), *NewRational(big.NewRat(5, 11), big.NewRat(1, 1))},
+	}
+	b := NewBallMatrix(64)
+	b.SetMatrix(&a)
+	if !b.Contains(&a) {
+		t.Fatal("invalid enclosure")
+	}
+
+	m := NewMatrix(64)
+	m.Mul(&a, &a)
+	c := NewBallMatrix(64)
+	c.Mul(&b, &b)
+	if !c.Contains(&m) {
+		t.Fatal("invalid enclosure", c.String())
+	}
+	m.Add(&m, &a)
+	c.Add(&c, &b)
+	if !c.Contains(&m) {
+		t.Fatal("invalid enclosure", c.String())
+	}
+	m.Sub(&m, &a)
+	c.Sub(&c, &b)
+	if !c.Contains(&m) {
+		t.Fatal("invalid enclosure", c.String())
+	}
+
+	e := NewBallMatrix(64)
+	e.Exp(&b)
+	for i, row := range a.Values {
+		for j := range row {
+			x := newFloat(512)
+			x.SetRat(&row[j])
+			if !e.Values[i][j].Contains(x.Exp(x)) {
+				t.Fatal("invalid enclosure", e.String())
+			}
+		}
+	}
+
+	s := NewBallMatrix(64)
+	s.Values = [][]Ball{{*NewBall(NewFloat(big.NewFloat(2).SetPrec(64), big.NewFloat(0).SetPrec(64)), nil)}}
+	s.Mul(&s, &b)
+	if s.String() != "[(0.6666666667) +/- 1.81e-20 (4 + 0.2857142857i) +/- 3.87e-21;(0 + -2i) +/- 0 (0.9090909091 + 2i) +/- 2.46e-20]" {
+		t.Fatal("invalid result", s.String())
+	}
+}
@@ -0,0 +1,792 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// besselGuard is the number of guard bits used by the bessel functions
+const besselGuard = 32
+
+var (
+	bernoulliMutex sync.Mutex
+	bernoulliA     []*big.Rat
+	bernoulliB     []*big.Rat
+)
+
+// bernoulli computes the bernoulli number B_n with the Akiyama-Tanigawa algorithm
+// https://en.wikipedia.org/wiki/Bernoulli_number#Algorithmic_description
+func bernoulli(n int) *big.Rat {
+	bernoulliMutex.Lock()
+	defer bernoulliMutex.Unlock()
+	for m := len(bernoulliB); m <= n; m++ {
+		bernoulliA = append(bernoulliA, big.NewRat(1, int64(m+1)))
+		for j := m; j > 0; j-- {
+			a := bernoulliA[j-1]
+			a.Sub(a, bernoulliA[j])
+			a.Mul(a, big.NewRat(int64(j), 1))
+		}
+		bernoulliB = append(bernoulliB, big.NewRat(0, 1).Set(bernoulliA[0]))
+	}
+	return bernoulliB[n]
+}
+
+// gamma computes the gamma function of a real x with the Stirling series
+// https://en.wikipedia.org/wiki/Stirling%27s_approximation#Speed_of_convergence_and_error_estimates
+func gamma(x *big.Float) *big.Float {
+	prec := x.Prec()
+	wp := prec + besselGuard
+	one := big.NewFloat(1).SetPrec(wp)
+	y := big.NewFloat(0).SetPrec(wp).Set(x)
+	p := big.NewFloat(1).SetPrec(wp)
+	limit := float64(wp)*math.Ln2/(2*math.Pi) + 1
+	for value, _ := y.Float64(); value < limit; value, _ = y.Float64() {
+		p.Mul(p, y)
+		y.Add(y, one)
+	}
+
+	// ln gamma(y) = (y - 1/2) ln(y) - y + ln(2pi)/2 + sum B_2k/(2k(2k-1)y^(2k-1))
+	lg := big.NewFloat(0).SetPrec(wp)
+	lg.Sub(y, big.NewFloat(.5).SetPrec(wp))
+	lg.Mul(lg, bigfloat.Log(y))
+	lg.Sub(lg, y)
+	pi := bigfloat.PI(wp)
+	pi.Add(pi, pi)
+	pi = bigfloat.Log(pi)
+	pi.Quo(pi, big.NewFloat(2).SetPrec(wp))
+	lg.Add(lg, pi)
+	yy, power := big.NewFloat(0).SetPrec(wp), big.NewFloat(0).SetPrec(wp).Set(y)
+	yy.Mul(y, y)
+	for k := 1; ; k++ {
+		term := big.NewFloat(0).SetPrec(wp).SetRat(bernoulli(2 * k))
+		term.Quo(term, big.NewFloat(float64(2*k*(2*k-1))).SetPrec(wp))
+		term.Quo(term, power)
+		lg.Add(lg, term)
+		if term.MantExp(nil) < lg.MantExp(nil)-int(wp) {
+			break
+		}
+		power.Mul(power, yy)
+	}
+
+	g := bigfloat.Exp(lg)
+	g.Quo(g, p)
+	return g.SetPrec(prec)
+}
+
+// euler computes the Euler-Mascheroni constant with the Brent-McMillan algorithm
+// https://en.wikipedia.org/wiki/Euler%27s_constant#Computation
+func euler(prec uint) *big.Float {
+	wp := prec + besselGuard
+	n := int64(float64(wp)*math.Ln2/4) + 1
+	nn := big.NewFloat(float64(n * n)).SetPrec(wp)
+	a := bigfloat.Log(big.NewFloat(float64(n)).SetPrec(wp))
+	a.Neg(a)
+	b := big.NewFloat(1).SetPrec(wp)
+	u := big.NewFloat(0).SetPrec(wp).Set(a)
+	v := big.NewFloat(1).SetPrec(wp)
+	for k := int64(1); ; k++ {
+		kk := big.NewFloat(float64(k)).SetPrec(wp)
+		b.Mul(b, nn)
+		b.Quo(b, kk)
+		b.Quo(b, kk)
+		a.Mul(a, nn)
+		a.Quo(a, kk)
+		a.Add(a, b)
+		a.Quo(a, kk)
+		u.Add(u, a)
+		v.Add(v, b)
+		if k > n && b.MantExp(nil) < v.MantExp(nil)-int(wp) &&
+			(a.Sign() == 0 || a.MantExp(nil) < u.MantExp(nil)-int(wp)) {
+			break
+		}
+	}
+	return u.Quo(u, v).SetPrec(prec)
+}
+
+// order returns the order as an integer if it is one
+func order(nu *big.Float) (int, bool) {
+	if !nu.IsInt() {
+		return 0, false
+	}
+	n, accuracy := nu.Int64()
+	if accuracy != big.Exact || n > math.MaxInt32 || n < math.MinInt32 {
+		return 0, false
+	}
+	return int(n), true
+}
+
+// besselLarge determines if the asymptotic expansions should be used instead of the series
+func besselLarge(nu *big.Float, z *Float, prec uint) bool {
+	v, _ := nu.Float64()
+	return math.Sqrt(norm64(z)) >= float64(prec)*math.Ln2/2+v*v+2
+}
+
+// besselPhase computes e^(i m nu pi)
+func besselPhase(nu *big.Float, m int, prec uint) *Float {
+	x := newFloat(prec)
+	x.B.Mul(nu, bigfloat.PI(prec))
+	x.B.Mul(x.B, big.NewFloat(float64(m)).SetPrec(prec))
+	return exp(x, prec)
+}
+
+// besselSinCos computes sin(nu pi) and cos(nu pi)
+func besselSinCos(nu *big.Float, prec uint) (*big.Float, *big.Float) {
+	x := besselPhase(nu, 1, prec)
+	return x.B, x.A
+}
+
+// besselPow computes (z/2)^nu
+func besselPow(nu *big.Float, z *Float, prec uint) *Float {
+	if z.A.Sign() == 0 && z.B.Sign() == 0 && !nu.IsInt() {
+		x := newFloat(prec)
+		if nu.Sign() < 0 {
+			x.A.SetInf(false)
+		}
+		return x
+	}
+	half := z.clone(prec)
+	half.A.Quo(half.A, big.NewFloat(2).SetPrec(prec))
+	half.B.Quo(half.B, big.NewFloat(2).SetPrec(prec))
+	if n, ok := order(nu); ok {
+		negative := n < 0
+		if negative {
+			n = -n
+		}
+		x := NewFloat(big.NewFloat(1).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+		for n > 0 {
+			if n&1 == 1 {
+				x.Mul(x, half)
+			}
+			half.Mul(half, half)
+			n >>= 1
+		}
+		if negative {
+			x.inv(x)
+		}
+		return x
+	}
+
+	x := log(half, prec)
+	x.A.Mul(x.A, nu)
+	x.B.Mul(x.B, nu)
+	return exp(x, prec)
+}
+
+// besselSeries computes (z/2)^nu sum (sign z^2/4)^k/(k! gamma(nu+k+1))
+// https://dlmf.nist.gov/10.2.E2
+func besselSeries(nu *big.Float, z *Float, sign int, prec uint) *Float {
+	wp := prec + uint(math.Sqrt(norm64(z))*math.Log2E) + 16
+	z = z.clone(wp)
+	nu = big.NewFloat(0).SetPrec(wp).Set(nu)
+	zz := newFloat(wp)
+	zz.Mul(z, z)
+	q := big.NewFloat(float64(sign) / 4).SetPrec(wp)
+	zz.A.Mul(zz.A, q)
+	zz.B.Mul(zz.B, q)
+	eps := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -2*int(prec+8))
+	peak := math.Sqrt(norm64(z))
+
+	g := big.NewFloat(1).SetPrec(wp)
+	g.Add(g, nu)
+	g = gamma(g)
+	term := NewFloat(big.NewFloat(1).SetPrec(wp), big.NewFloat(0).SetPrec(wp))
+	term.A.Quo(term.A, g)
+	sum := term.clone(wp)
+	scale := norm(sum)
+	k, d := big.NewFloat(0).SetPrec(wp), big.NewFloat(0).SetPrec(wp)
+	for n := 1; ; n++ {
+		k.SetInt64(int64(n))
+		d.Add(nu, k)
+		d.Mul(d, k)
+		term.Mul(term, zz)
+		term.A.Quo(term.A, d)
+		term.B.Quo(term.B, d)
+		sum.Add(sum, term)
+		if float64(n) > peak && norm(term).Cmp(big.NewFloat(0).SetPrec(wp).Mul(scale, eps)) < 0 {
+			break
+		}
+	}
+	return sum.Mul(sum, besselPow(nu, z, wp))
+}
+
+// besselLogSeries computes the logarithmic series of the integer order Y and K functions
+// (z/2)^n sum (psi(k+1) + psi(n+k+1)) (sign z^2/4)^k/(k!(n+k)!)
+// https://dlmf.nist.gov/10.8.E1
+func besselLogSeries(n int, z *Float, sign int, prec uint) *Float {
+	wp := prec + uint(math.Sqrt(norm64(z))*math.Log2E) + 16
+	z = z.clone(wp)
+	zz := newFloat(wp)
+	zz.Mul(z, z)
+	q := big.NewFloat(float64(sign) / 4).SetPrec(wp)
+	zz.A.Mul(zz.A, q)
+	zz.B.Mul(zz.B, q)
+	eps := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -2*int(prec+8))
+	peak := math.Sqrt(norm64(z))
+
+	// psi(m+1) = -gamma + H_m
+	gamma := euler(wp)
+	gamma.Neg(gamma)
+	psi1 := big.NewFloat(0).SetPrec(wp).Set(gamma)
+	psi2 := big.NewFloat(0).SetPrec(wp).Set(gamma)
+	factorial := big.NewFloat(1).SetPrec(wp)
+	for i := 1; i <= n; i++ {
+		psi2.Add(psi2, big.NewFloat(0).SetPrec(wp).Quo(big.NewFloat(1).SetPrec(wp), big.NewFloat(float64(i)).SetPrec(wp)))
+		factorial.Mul(factorial, big.NewFloat(float64(i)).SetPrec(wp))
+	}
+	term := NewFloat(big.NewFloat(1).SetPrec(wp), big.NewFloat(0).SetPrec(wp))
+	term.A.Quo(term.A, factorial)
+	sum := term.clone(wp)
+	c := big.NewFloat(0).SetPrec(wp).Add(psi1, psi2)
+	sum.A.Mul(sum.A, c)
+	scale := norm(term)
+	t, d := newFloat(wp), big.NewFloat(0).SetPrec(wp)
+	for k := 1; ; k++ {
+		d.SetInt64(int64(k * (n + k)))
+		term.Mul(term, zz)
+		term.A.Quo(term.A, d)
+		term.B.Quo(term.B, d)
+		psi1.Add(psi1, big.NewFloat(0).SetPrec(wp).Quo(big.NewFloat(1).SetPrec(wp), big.NewFloat(float64(k)).SetPrec(wp)))
+		psi2.Add(psi2, big.NewFloat(0).SetPrec(wp).Quo(big.NewFloat(1).SetPrec(wp), big.NewFloat(float64(n+k)).SetPrec(wp)))
+		c.Add(psi1, psi2)
+		t.A.Mul(term.A, c)
+		t.B.Mul(term.B, c)
+		sum.Add(sum, t)
+		if float64(k) > peak && norm(t).Cmp(big.NewFloat(0).SetPrec(wp).Mul(scale, eps)) < 0 {
+			break
+		}
+	}
+	return sum.Mul(sum, besselPow(big.NewFloat(float64(n)).SetPrec(wp), z, wp))
+}
+
+// besselFinite computes the finite sum of the integer order Y and K functions
+// (z/2)^-n sum_{k<n} (n-k-1)!/k! (sign z^2/4)^k
+func besselFinite(n int, z *Float, sign int, prec uint) *Float {
+	zz := newFloat(prec)
+	zz.Mul(z, z)
+	q := big.NewFloat(float64(sign) / 4).SetPrec(prec)
+	zz.A.Mul(zz.A, q)
+	zz.B.Mul(zz.B, q)
+	sum, power := newFloat(prec), NewFloat(big.NewFloat(1).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+	for k := 0; k < n; k++ {
+		a, b := big.NewInt(1), big.NewInt(1)
+		a.MulRange(1, int64(n-k-1))
+		b.MulRange(1, int64(k))
+		c := big.NewFloat(0).SetPrec(prec).SetRat(big.NewRat(1, 1).SetFrac(a, b))
+		sum.A.Add(sum.A, big.NewFloat(0).SetPrec(prec).Mul(power.A, c))
+		sum.B.Add(sum.B, big.NewFloat(0).SetPrec(prec).Mul(power.B, c))
+		power.Mul(power, zz)
+	}
+	return sum.Mul(sum, besselPow(big.NewFloat(float64(-n)).SetPrec(prec), z, prec))
+}
+
+// besselAsymptotic computes the sums of the Hankel expansions for Re(z) >= 0
+// sum a_k(nu)/z^k, sum i^k a_k(nu)/z^k and sum (-i)^k a_k(nu)/z^k
+// https://dlmf.nist.gov/10.17
+func besselAsymptotic(nu *big.Float, z *Float, prec uint) (*Float, *Float, *Float) {
+	four := big.NewFloat(0).SetPrec(prec)
+	four.Mul(nu, nu)
+	four.Mul(four, big.NewFloat(4).SetPrec(prec))
+	inv := newFloat(prec)
+	inv.inv(z)
+	eps := big.NewFloat(0).SetPrec(prec).SetMantExp(big.NewFloat(1).SetPrec(prec), -2*int(prec))
+
+	s := NewFloat(big.NewFloat(1).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+	s1, s2 := s.clone(prec), s.clone(prec)
+	term, c := s.clone(prec), big.NewFloat(0).SetPrec(prec)
+	last := norm(term)
+	for k := 1; ; k++ {
+		c.SetInt64(int64((2*k - 1) * (2*k - 1)))
+		c.Sub(four, c)
+		c.Quo(c, big.NewFloat(float64(8*k)).SetPrec(prec))
+		term.Mul(term, inv)
+		term.A.Mul(term.A, c)
+		term.B.Mul(term.B, c)
+		s.Add(s, term)
+		// multiply by i^k and (-i)^k
+		switch k % 4 {
+		case 0:
+			s1.Add(s1, term)
+			s2.Add(s2, term)
+		case 1:
+			s1.A.Sub(s1.A, term.B)
+			s1.B.Add(s1.B, term.A)
+			s2.A.Add(s2.A, term.B)
+			s2.B.Sub(s2.B, term.A)
+		case 2:
+			s1.Sub(s1, term)
+			s2.Sub(s2, term)
+		case 3:
+			s1.A.Add(s1.A, term.B)
+			s1.B.Sub(s1.B, term.A)
+			s2.A.Sub(s2.A, term.B)
+			s2.B.Add(s2.B, term.A)
+		}
+		n := norm(term)
+		if n.Sign() == 0 || n.Cmp(eps) < 0 || (k > 1 && n.Cmp(last) > 0) {
+			break
+		}
+		last = n
+	}
+	return s, s1, s2
+}
+
+// hankelAsymptotic computes the Hankel functions for large z with Re(z) >= 0
+// H1(z) = sqrt(2/(pi z)) e^(i w) sum i^k a_k(nu)/z^k, w = z - nu pi/2 - pi/4
+// H2(z) = sqrt(2/(pi z)) e^(-i w) sum (-i)^k a_k(nu)/z^k
+// https://dlmf.nist.gov/10.17.E5
+func hankelAsymptotic(nu *big.Float, z *Float, prec uint) (*Float, *Float) {
+	_, s1, s2 := besselAsymptotic(nu, z, prec)
+	pi := bigfloat.PI(prec)
+	c := z.clone(prec)
+	c.A.Mul(c.A, pi)
+	c.B.Mul(c.B, pi)
+	c.inv(c)
+	c.A.Add(c.A, c.A)
+	c.B.Add(c.B, c.B)
+	r := sqrt(c, prec)
+
+	// the phase keeps the bits of z below the unit, which are exact, with exponent(z) more bits
+	wp := prec
+	if e := z.A.MantExp(nil); z.A.Sign() != 0 && e > 0 {
+		wp += uint(e)
+	}
+	w := big.NewFloat(0).SetPrec(wp).Set(z.A)
+	shift := big.NewFloat(0).SetPrec(wp)
+	shift.Add(nu, nu)
+	shift.Add(shift, big.NewFloat(1).SetPrec(wp))
+	shift.Mul(shift, bigfloat.PI(wp))
+	shift.Quo(shift, big.NewFloat(4).SetPrec(wp))
+	w.Sub(w, shift)
+	sin, cos := sinCos(w, prec)
+	// e^(iw) = e^(-Im z) (cos + i sin) and e^(-iw) = e^(Im z) (cos - i sin)
+	g := bigfloat.Exp(big.NewFloat(0).SetPrec(prec).Neg(z.B))
+	h := bigfloat.Exp(big.NewFloat(0).SetPrec(prec).Set(z.B))
+	e1 := NewFloat(big.NewFloat(0).SetPrec(prec).Mul(g, cos), big.NewFloat(0).SetPrec(prec).Mul(g, sin))
+	e2 := NewFloat(big.NewFloat(0).SetPrec(prec).Mul(h, cos), big.NewFloat(0).SetPrec(prec).Mul(h, sin))
+	e2.B.Neg(e2.B)
+	s1.Mul(s1, e1)
+	s1.Mul(s1, r)
+	s2.Mul(s2, e2)
+	s2.Mul(s2, r)
+	return s1, s2
+}
+
+// besselKAsymptotic computes K for large z with Re(z) >= 0
+// K(z) = sqrt(pi/(2z)) e^(-z) sum a_k(nu)/z^k
+// https://dlmf.nist.gov/10.40.E2
+func besselKAsymptotic(nu *big.Float, z *Float, prec uint) *Float {
+	s, _, _ := besselAsymptotic(nu, z, prec)
+	c := z.clone(prec)
+	c.A.Add(c.A, c.A)
+	c.B.Add(c.B, c.B)
+	c.inv(c)
+	pi := bigfloat.PI(prec)
+	c.A.Mul(c.A, pi)
+	c.B.Mul(c.B, pi)
+	r := sqrt(c, prec)
+	e := z.clone(prec)
+	e.A.Neg(e.A)
+	e.B.Neg(e.B)
+	e = exp(e, prec)
+	s.Mul(s, e)
+	return s.Mul(s, r)
+}
+
+// besselJY computes J and Y for large z with the Hankel expansions
+// https://dlmf.nist.gov/10.11
+func besselJY(nu *big.Float, z *Float, prec uint) (*Float, *Float) {
+	if z.A.Sign() >= 0 {
+		h1, h2 := hankelAsymptotic(nu, z, prec)
+		j, y := newFloat(prec), newFloat(prec)
+		j.Add(h1, h2)
+		y.Sub(h1, h2)
+		half := big.NewFloat(.5).SetPrec(prec)
+		j.A.Mul(j.A, half)
+		j.B.Mul(j.B, half)
+		// (h1 - h2)/(2i)
+		y.A, y.B = y.B.Mul(y.B, half), y.A.Mul(y.A, half).Neg(y.A)
+		return j, y
+	}
+
+	// J(ue^(i m pi)) = e^(i m nu pi) J(u)
+	// Y(ue^(i m pi)) = e^(-i m nu pi) Y(u) + 2i m cos(nu pi) J(u)
+	u := z.clone(prec)
+	u.A.Neg(u.A)
+	u.B.Neg(u.B)
+	m := 1
+	if z.B.Sign() < 0 {
+		m = -1
+	}
+	ju, yu := besselJY(nu, u, prec)
+	j := newFloat(prec)
+	j.Mul(ju, besselPhase(nu, m, prec))
+	y := newFloat(prec)
+	y.Mul(yu, besselPhase(nu, -m, prec))
+	_, cos := besselSinCos(nu, prec)
+	cos.Mul(cos, big.NewFloat(float64(2*m)).SetPrec(prec))
+	y.A.Sub(y.A, big.NewFloat(0).SetPrec(prec).Mul(ju.B, cos))
+	y.B.Add(y.B, big.NewFloat(0).SetPrec(prec).Mul(ju.A, cos))
+	return j, y
+}
+
+// besselJ computes the bessel function of the first kind
+func besselJ(nu *big.Float, z *Float, prec uint) *Float {
+	if n, ok := order(nu); ok && n < 0 {
+		// J_-n(z) = (-1)^n J_n(z)
+		j := besselJ(big.NewFloat(float64(-n)).SetPrec(prec), z, prec)
+		if n%2 != 0 {
+			j.A.Neg(j.A)
+			j.B.Neg(j.B)
+		}
+		return j
+	}
+	if !besselLarge(nu, z, prec) {
+		return besselSeries(nu, z, -1, prec)
+	}
+	j, _ := besselJY(nu, z, prec)
+	return j
+}
+
+// besselY computes the bessel function of the second kind
+// https://dlmf.nist.gov/10.2.E3
+func besselY(nu *big.Float, z *Float, prec uint) *Float {
+	n, integer := order(nu)
+	if integer && n < 0 {
+		// Y_-n(z) = (-1)^n Y_n(z)
+		y := besselY(big.NewFloat(float64(-n)).SetPrec(prec), z, prec)
+		if n%2 != 0 {
+			y.A.Neg(y.A)
+			y.B.Neg(y.B)
+		}
+		return y
+	}
+	if besselLarge(nu, z, prec) {
+		_, y := besselJY(nu, z, prec)
+		return y
+	}
+
+	if integer {
+		// Y_n(z) = -(z/2)^-n/pi sum_{k<n} (n-k-1)!/k! (z^2/4)^k + 2/pi ln(z/2) J_n(z)
+		//   - (z/2)^n/pi sum (psi(k+1) + psi(n+k+1)) (-z^2/4)^k/(k!(n+k)!)
+		half := z.clone(prec)
+		half.A.Quo(half.A, big.NewFloat(2).SetPrec(prec))
+		half.B.Quo(half.B, big.NewFloat(2).SetPrec(prec))
+		l := log(half, prec)
+		l.A.Add(l.A, l.A)
+		l.B.Add(l.B, l.B)
+		y := newFloat(prec)
+		y.Mul(l, besselJ(nu, z, prec))
+		y.Sub(y, besselFinite(n, z, 1, prec))
+		y.Sub(y, besselLogSeries(n, z, -1, prec))
+		pi := bigfloat.PI(prec)
+		y.A.Quo(y.A, pi)
+		y.B.Quo(y.B, pi)
+		return y
+	}
+
+	// Y(z) = (J(z) cos(nu pi) - J_-nu(z))/sin(nu pi)
+	sin, cos := besselSinCos(nu, prec)
+	j := besselJ(nu, z, prec)
+	j.A.Mul(j.A, cos)
+	j.B.Mul(j.B, cos)
+	j.Sub(j, besselJ(big.NewFloat(0).SetPrec(prec).Neg(nu), z, prec))
+	j.A.Quo(j.A, sin)
+	j.B.Quo(j.B, sin)
+	return j
+}
+
+// besselI computes the modified bessel function of the first kind
+// https://dlmf.nist.gov/10.25.E2
+func besselI(nu *big.Float, z *Float, prec uint) *Float {
+	if n, ok := order(nu); ok && n < 0 {
+		// I_-n(z) = I_n(z)
+		return besselI(big.NewFloat(float64(-n)).SetPrec(prec), z, prec)
+	}
+	if !besselLarge(nu, z, prec) {
+		return besselSeries(nu, z, 1, prec)
+	}
+
+	// I(z) = e^(-i nu pi/2) J(iz) for -pi < ph(z) <= pi/2
+	// I(z) = e^(i nu pi/2) J(-iz) for pi/2 < ph(z) <= pi
+	half := big.NewFloat(0).SetPrec(prec).Quo(nu, big.NewFloat(2).SetPrec(prec))
+	if z.A.Sign() < 0 && z.B.Sign() > 0 {
+		iz := NewFloat(big.NewFloat(0).SetPrec(prec).Set(z.B), big.NewFloat(0).SetPrec(prec).Neg(z.A))
+		j := besselJ(nu, iz, prec)
+		return j.Mul(j, besselPhase(half, 1, prec))
+	}
+	iz := NewFloat(big.NewFloat(0).SetPrec(prec).Neg(z.B), big.NewFloat(0).SetPrec(prec).Set(z.A))
+	j := besselJ(nu, iz, prec)
+	return j.Mul(j, besselPhase(half, -1, prec))
+}
+
+// besselK computes the modified bessel function of the second kind
+// https://dlmf.nist.gov/10.27.E4
+func besselK(nu *big.Float, z *Float, prec uint) *Float {
+	if nu.Sign() < 0 {
+		// K_-nu(z) = K_nu(z)
+		nu = big.NewFloat(0).SetPrec(prec).Neg(nu)
+	}
+	if besselLarge(nu, z, prec) {
+		if z.A.Sign() >= 0 {
+			return besselKAsymptotic(nu, z, prec)
+		}
+		// K(ue^(i m pi)) = e^(-i m nu pi) K(u) - i m pi I(u)
+		u := z.clone(prec)
+		u.A.Neg(u.A)
+		u.B.Neg(u.B)
+		m := 1
+		if z.B.Sign() < 0 {
+			m = -1
+		}
+		k := besselKAsymptotic(nu, u, prec)
+		k.Mul(k, besselPhase(nu, -m, prec))
+		i := besselI(nu, u, prec)
+		pi := bigfloat.PI(prec)
+		pi.Mul(pi, big.NewFloat(float64(m)).SetPrec(prec))
+		k.A.Add(k.A, big.NewFloat(0).SetPrec(prec).Mul(i.B, pi))
+		k.B.Sub(k.B, big.NewFloat(0).SetPrec(prec).Mul(i.A, pi))
+		return k
+	}
+
+	// the series of I cancel down to K which is smaller by e^(-2|z|)
+	prec += uint(2 * math.Sqrt(norm64(z)) * math.Log2E)
+	if n, ok := order(nu); ok {
+		// K_n(z) = (z/2)^-n/2 sum_{k<n} (n-k-1)!/k! (-z^2/4)^k + (-1)^(n+1) ln(z/2) I_n(z)
+		//   + (-1)^n (z/2)^n/2 sum (psi(k+1) + psi(n+k+1)) (z^2/4)^k/(k!(n+k)!)
+		// https://dlmf.nist.gov/10.31.E1
+		half := z.clone(prec)
+		half.A.Quo(half.A, big.NewFloat(2).SetPrec(prec))
+		half.B.Quo(half.B, big.NewFloat(2).SetPrec(prec))
+		l := log(half, prec)
+		k := newFloat(prec)
+		k.Mul(l, besselI(nu, z, prec))
+		s := besselLogSeries(n, z, 1, prec)
+		s.A.Quo(s.A, big.NewFloat(2).SetPrec(prec))
+		s.B.Quo(s.B, big.NewFloat(2).SetPrec(prec))
+		k.Sub(s, k)
+		if n%2 != 0 {
+			k.A.Neg(k.A)
+			k.B.Neg(k.B)
+		}
+		f := besselFinite(n, z, -1, prec)
+		f.A.Quo(f.A, big.NewFloat(2).SetPrec(prec))
+		f.B.Quo(f.B, big.NewFloat(2).SetPrec(prec))
+		return k.Add(k, f)
+	}
+
+	// K(z) = pi/2 (I_-nu(z) - I(z))/sin(nu pi)
+	sin, _ := besselSinCos(nu, prec)
+	k := besselI(big.NewFloat(0).SetPrec(prec).Neg(nu), z, prec)
+	k.Sub(k, besselI(nu, z, prec))
+	pi := bigfloat.PI(prec)
+	pi.Quo(pi, big.NewFloat(2).SetPrec(prec))
+	pi.Quo(pi, sin)
+	k.A.Mul(k.A, pi)
+	k.B.Mul(k.B, pi)
+	return k
+}
+
+// hankel computes the Hankel functions H1 = J + iY and H2 = J - iY. One of them decays like
+// e^(-|Im z|) where J and Y grow, so they are computed with 2|Im z| log2(e) more bits, or
+// with the asymptotic expansions for large z. The left half plane is continued from
+// u = -z with H1(ue^(i pi)) = -e^(-i nu pi) H2(u), H2(ue^(i pi)) = 2cos(nu pi) H2(u) +
+// e^(i nu pi) H1(u), H1(ue^(-i pi)) = 2cos(nu pi) H1(u) + e^(-i nu pi) H2(u) and
+// H2(ue^(-i pi)) = -e^(i nu pi) H1(u)
+// https://dlmf.nist.gov/10.11.E7
+// https://dlmf.nist.gov/10.11.E8
+func hankel(nu *big.Float, z *Float, prec uint) (*Float, *Float) {
+	if z.A.Sign() < 0 {
+		u := z.clone(prec)
+		u.A.Neg(u.A)
+		u.B.Neg(u.B)
+		m := 1
+		if z.B.Sign() < 0 {
+			m = -1
+		}
+		h1, h2 := hankel(nu, u, prec)
+		if m < 0 {
+			// the roles of H1 and H2 are exchanged below the real axis
+			h1, h2 = h2, h1
+		}
+		_, cos := besselSinCos(nu, prec)
+		cos.Add(cos, cos)
+		decaying := newFloat(prec).Mul(h2, besselPhase(nu, -m, prec))
+		decaying.A.Neg(decaying.A)
+		decaying.B.Neg(decaying.B)
+		growing := newFloat(prec).Mul(h1, besselPhase(nu, m, prec))
+		h2.A.Mul(h2.A, cos)
+		h2.B.Mul(h2.B, cos)
+		growing.Add(growing, h2)
+		if m < 0 {
+			return growing, decaying
+		}
+		return decaying, growing
+	}
+	if besselLarge(nu, z, prec) {
+		return hankelAsymptotic(nu, z, prec)
+	}
+	b, _ := z.B.Float64()
+	wp := prec + uint(2*math.Abs(b)*math.Log2E)
+	j, y := besselJ(nu, z, wp), besselY(nu, z, wp)
+	h1 := NewFloat(big.NewFloat(0).SetPrec(wp).Sub(j.A, y.B), big.NewFloat(0).SetPrec(wp).Add(j.B, y.A))
+	h2 := NewFloat(big.NewFloat(0).SetPrec(wp).Add(j.A, y.B), big.NewFloat(0).SetPrec(wp).Sub(j.B, y.A))
+	return h1, h2
+}
+
+// besselRetries is the number of times the bessel functions raise the working precision
+const besselRetries = 3
+
+// besselMissing computes the number of bits of the precision prec that the part y lacks, with
+// the error of y estimated by its difference from the more accurate part x
+func besselMissing(y, x *big.Float, prec uint) int {
+	if x.Sign() == 0 || y.Sign() == 0 {
+		if x.Sign() == y.Sign() {
+			return 0
+		}
+		return int(prec)
+	}
+	d := big.NewFloat(0).SetPrec(y.Prec()+x.Prec()).Sub(y, x)
+	if d.Sign() == 0 {
+		return 0
+	}
+	return d.MantExp(nil) - x.MantExp(nil) + int(prec)
+}
+
+// besselRefine computes function with the working precision prec + besselGuard and again with
+// besselGuard more bits. Near the zeros of the functions and for tiny imaginary parts the sums
+// cancel and lose more bits than the guard bits, which the approximations then disagree on by
+// more than an ulp of prec, so the working precision is raised by the missing bits
+func besselRefine(prec uint, function func(prec uint) *Float) *Float {
+	wp := prec + besselGuard
+	x := function(wp)
+	for i := 0; i < besselRetries; i++ {
+		wp += besselGuard
+		y := function(wp)
+		missing := besselMissing(x.A, y.A, prec)
+		if m := besselMissing(x.B, y.B, prec); m > missing {
+			missing = m
+		}
+		if y.IsInf() || missing <= 0 {
+			return y
+		}
+		wp += uint(missing)
+		x = y
+	}
+	return x
+}
+
+// besselReal determines if the bessel function of x is real
+func besselReal(x *Float) bool {
+	return x.B.Sign() == 0 && x.A.Sign() > 0
+}
+
+// besselZero sets f to the value of J and I of order nu at zero for the regular functions,
+// 1 for order 0, 0 for positive and integer orders and infinite otherwise, or to Y of order nu
+// at zero, 0 for negative half integer orders and infinite otherwise
+func (f *Float) besselZero(nu *big.Float, regular bool) *Float {
+	n, integer := order(nu)
+	m, half := order(big.NewFloat(0).SetMantExp(nu, 1))
+	f.B.SetInt64(0)
+	switch {
+	case regular && integer && n == 0:
+		f.A.SetInt64(1)
+	case regular && (integer || nu.Sign() > 0), !regular && half && m < 0 && m%2 != 0:
+		f.A.SetInt64(0)
+	default:
+		f.A.SetInf(false)
+	}
+	return f
+}
+
+// BesselJ computes the bessel function of the first kind of order nu
+// https://en.wikipedia.org/wiki/Bessel_function#Bessel_functions_of_the_first_kind:_J%CE%B1
+func (f *Float) BesselJ(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.besselZero(nu, true)
+		}
+		y := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			return besselJ(nu, x, prec)
+		})
+		return f.set(y, false, besselReal(x))
+	})
+}
+
+// BesselY computes the bessel function of the second kind of order nu
+// https://en.wikipedia.org/wiki/Bessel_function#Bessel_functions_of_the_second_kind:_Y%CE%B1
+func (f *Float) BesselY(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.besselZero(nu, false)
+		}
+		y := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			return besselY(nu, x, prec)
+		})
+		return f.set(y, false, besselReal(x))
+	})
+}
+
+// BesselI computes the modified bessel function of the first kind of order nu
+// https://en.wikipedia.org/wiki/Bessel_function#Modified_Bessel_functions:_I%CE%B1,_K%CE%B1
+func (f *Float) BesselI(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.besselZero(nu, true)
+		}
+		y := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			return besselI(nu, x, prec)
+		})
+		return f.set(y, false, besselReal(x))
+	})
+}
+
+// BesselK computes the modified bessel function of the second kind of order nu
+// https://en.wikipedia.org/wiki/Bessel_function#Modified_Bessel_functions:_I%CE%B1,_K%CE%B1
+func (f *Float) BesselK(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.SetInf()
+		}
+		y := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			return besselK(nu, x, prec)
+		})
+		return f.set(y, false, besselReal(x))
+	})
+}
+
+// HankelH1 computes the hankel function of the first kind of order nu, H1 = J + iY
+// https://en.wikipedia.org/wiki/Bessel_function#Hankel_functions:_H(1)%CE%B1,_H(2)%CE%B1
+func (f *Float) HankelH1(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.SetInf()
+		}
+		h1 := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			h1, _ := hankel(nu, x, prec)
+			return h1
+		})
+		return f.set(h1, false, false)
+	})
+}
+
+// HankelH2 computes the hankel function of the second kind of order nu, H2 = J - iY
+// https://en.wikipedia.org/wiki/Bessel_function#Hankel_functions:_H(1)%CE%B1,_H(2)%CE%B1
+func (f *Float) HankelH2(nu *big.Float, x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			return f.SetInf()
+		}
+		h2 := besselRefine(f.A.Prec(), func(prec uint) *Float {
+			_, h2 := hankel(nu, x, prec)
+			return h2
+		})
+		return f.set(h2, false, false)
+	})
+}
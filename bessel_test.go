@@ -0,0 +1,213 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestGamma(t *testing.T) {
+	a := gamma(big.NewFloat(.5).SetPrec(64))
+	t.Log(a.String())
+	if a.String() != "1.772453851" {
+		t.Fatal("invalid result")
+	}
+
+	a = gamma(big.NewFloat(-1.5).SetPrec(64))
+	t.Log(a.String())
+	if a.String() != "2.363271801" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestEuler(t *testing.T) {
+	a := euler(64)
+	t.Log(a.String())
+	if a.String() != "0.5772156649" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_BesselJ(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselJ(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.7651976866" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.BesselJ(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.9376084768 + -0.4965299476i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselJ(big.NewFloat(2.5).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.04949681023" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(40).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselJ(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.007366890584" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-40).SetPrec(64), big.NewFloat(3).SetPrec(64))
+	a.BesselJ(big.NewFloat(-2).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.0362034056 + -1.257830029i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_BesselJLarge(t *testing.T) {
+	// the phase 2^64 - pi/4 needs the 64 bits of the argument below the unit
+	a := NewFloat(big.NewFloat(0).SetMantExp(big.NewFloat(1), 64).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselJ(big.NewFloat(0), a)
+	if s := a.A.Text('g', 19); s != "-1.282241271156057087e-10" || a.B.Sign() != 0 {
+		t.Fatal("invalid result", s)
+	}
+
+	// the imaginary part of the hankel functions cancels in J = (H1 + H2)/2
+	a = NewFloat(big.NewFloat(0).SetPrec(64).SetMantExp(big.NewFloat(-1), 64), big.NewFloat(0).SetPrec(64).SetMantExp(big.NewFloat(1), -64))
+	b := newFloat(1024).BesselJ(big.NewFloat(0), a)
+	a.BesselJ(big.NewFloat(0), a)
+	t.Log(a.String())
+	if a.String() != "-1.282241271e-10 + 7.287137315e-30i" || !faithful(a.A, b.A) || !faithful(a.B, b.B) {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_BesselY(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselY(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.08825696422" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselY(big.NewFloat(.5).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "-0.431098868" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-2).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.BesselY(big.NewFloat(1).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.1761808262 + -0.9818399433i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-40).SetPrec(64), big.NewFloat(3).SetPrec(64))
+	a.BesselY(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "-1.255791165 + 0.02745790499i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_BesselI(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselI(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "1.266065878" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(30).SetPrec(64), big.NewFloat(-50).SetPrec(64))
+	a.BesselI(big.NewFloat(1).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "3.975190395e+11 + 3.894206066e+11i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_BesselK(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselK(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.4210244382" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselK(big.NewFloat(.5).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.4610685044" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(40).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.BesselK(big.NewFloat(3).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "9.378903725e-19" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-40).SetPrec(64), big.NewFloat(3).SetPrec(64))
+	a.BesselK(big.NewFloat(1).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "-8.1839158e+15 + 4.541085664e+16i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Hankel(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.HankelH1(big.NewFloat(1).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.4400505857 + -0.7812128213i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.HankelH2(big.NewFloat(1).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.4400505857 + 0.7812128213i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(40).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.HankelH1(big.NewFloat(0).SetPrec(64), a)
+	t.Log(a.String())
+	if a.String() != "0.007366890584 + 0.1259364171i" {
+		t.Fatal("invalid result")
+	}
+
+	// H1 decays in the upper half plane where J and Y grow like e^150
+	a = NewFloat(big.NewFloat(-0.01408578118).SetPrec(64), big.NewFloat(149.7347101).SetPrec(64))
+	b := newFloat(512).HankelH1(big.NewFloat(.5), a)
+	a.HankelH1(big.NewFloat(.5), a)
+	t.Log(a.String())
+	if a.String() != "-4.373784082e-67 + -4.251871159e-67i" || a.A.Cmp(b.A.SetPrec(64)) != 0 || a.B.Cmp(b.B.SetPrec(64)) != 0 {
+		t.Fatal("invalid result")
+	}
+
+	// Y of order 1/2 = -sqrt(2/(pi x)) cos x vanishes at pi/2, where its series cancels
+	a = NewFloat(big.NewFloat(math.Pi/2).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	b = newFloat(1024).HankelH1(big.NewFloat(.5), a)
+	a.HankelH1(big.NewFloat(.5), a)
+	t.Log(a.String())
+	if a.String() != "0.6366197724 + -3.898171833e-17i" || !faithful(a.A, b.A) || !faithful(a.B, b.B) {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-9.793162852).SetPrec(64), big.NewFloat(-47.93792221).SetPrec(64))
+	b = newFloat(512).HankelH2(big.NewFloat(.5), a)
+	a.HankelH2(big.NewFloat(.5), a)
+	t.Log(a.String())
+	if a.String() != "1.643976701e-22 + -5.379729177e-23i" || a.A.Cmp(b.A.SetPrec(64)) != 0 || a.B.Cmp(b.B.SetPrec(64)) != 0 {
+		t.Fatal("invalid result")
+	}
+}
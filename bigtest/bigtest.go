@@ -0,0 +1,325 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package bigtest measures the accuracy of the Float functions by evaluating them with
+// the precision p and with the reference precision 2p + 64, and reporting the errors of
+// the real and imaginary parts in units in the last place for random and adversarial inputs
+// https://en.wikipedia.org/wiki/Unit_in_the_last_place
+package bigtest
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+
+	c0mpl3x "github.com/pointlander/c0mpl3x"
+)
+
+// The bounds of the errors of the functions in ulp
+const (
+	// Correct is the bound of the correctly rounded functions
+	Correct = .5
+	// Faithful is the bound of the functions that are computed with guard bits and rounded
+	// once, which isn't certified to be the correct rounding, but is within an ulp of the value
+	Faithful = 1
+)
+
+// Function is a Float function that stores its value for the arguments x in z
+type Function struct {
+	Name string
+	// Arity is the number of arguments
+	Arity int
+	// Rounded is true if the function is correctly rounded
+	Rounded bool
+	// Bound is the largest error of the parts in ulp that the function is documented to have
+	Bound float64
+	Eval  func(z *c0mpl3x.Float, x []*c0mpl3x.Float) *c0mpl3x.Float
+}
+
+// unary creates a function of one argument with the error bound in ulp
+func unary(name string, bound float64, f func(z, x *c0mpl3x.Float) *c0mpl3x.Float) Function {
+	return Function{
+		Name:    name,
+		Arity:   1,
+		Rounded: bound == Correct,
+		Bound:   bound,
+		Eval: func(z *c0mpl3x.Float, x []*c0mpl3x.Float) *c0mpl3x.Float {
+			return f(z, x[0])
+		},
+	}
+}
+
+// binary creates a function of two arguments with the error bound in ulp
+func binary(name string, bound float64, f func(z, x, y *c0mpl3x.Float) *c0mpl3x.Float) Function {
+	return Function{
+		Name:    name,
+		Arity:   2,
+		Rounded: bound == Correct,
+		Bound:   bound,
+		Eval: func(z *c0mpl3x.Float, x []*c0mpl3x.Float) *c0mpl3x.Float {
+			return f(z, x[0], x[1])
+		},
+	}
+}
+
+// bessel creates a bessel function of the order nu, which is faithful because the working
+// precision is raised until the cancellation near the zeros is resolved
+func bessel(name string, nu float64, f func(z *c0mpl3x.Float, nu *big.Float, x *c0mpl3x.Float) *c0mpl3x.Float) Function {
+	return unary(fmt.Sprintf("%s(%g)", name, nu), Faithful, func(z, x *c0mpl3x.Float) *c0mpl3x.Float {
+		return f(z, big.NewFloat(nu), x)
+	})
+}
+
+// lambertW creates the branch k of the Lambert W function, which is faithful because the
+// working precision is raised near the branch point -1/e
+func lambertW(k int) Function {
+	return unary(fmt.Sprintf("LambertW(%d)", k), Faithful, func(z, x *c0mpl3x.Float) *c0mpl3x.Float {
+		return z.LambertW(k, x)
+	})
+}
+
+// Functions are the functions of the package. The elementary functions that round once are
+// correctly rounded, and the others, which combine several rounded values or sum series, are
+// faithful, with the cancellation near their zeros resolved by a higher working precision
+var Functions = []Function{
+	binary("Add", Correct, (*c0mpl3x.Float).Add),
+	binary("Sub", Correct, (*c0mpl3x.Float).Sub),
+	binary("Mul", Correct, (*c0mpl3x.Float).Mul),
+	binary("Div", Faithful, (*c0mpl3x.Float).Div), // the exact numerator and the norm are rounded before the quotient
+	unary("Abs", Faithful, (*c0mpl3x.Float).Abs),  // the norm is rounded before the square root
+	unary("Conj", Correct, (*c0mpl3x.Float).Conj),
+	unary("Atan2", Faithful, (*c0mpl3x.Float).Atan2), // the quotient of the parts is rounded before the arctangent
+	unary("Arg", Faithful, (*c0mpl3x.Float).Arg),     // the quotient of the parts is rounded before the arctangent
+	unary("Sqrt", Correct, (*c0mpl3x.Float).Sqrt),
+	unary("Exp", Correct, (*c0mpl3x.Float).Exp),
+	unary("Exp2", Faithful, (*c0mpl3x.Float).Exp2),   // the product with log 2 is rounded before the exponential
+	unary("Exp10", Faithful, (*c0mpl3x.Float).Exp10), // the product with log 10 is rounded before the exponential
+	unary("Expm1", Faithful, (*c0mpl3x.Float).Expm1), // exp(x) - 1 is summed with guard bits near zero
+	unary("Log", Correct, (*c0mpl3x.Float).Log),
+	unary("Log2", Faithful, (*c0mpl3x.Float).Log2),        // the log is rounded before the quotient by log 2
+	unary("Log10", Faithful, (*c0mpl3x.Float).Log10),      // the log is rounded before the quotient by log 10
+	unary("Log1p", Faithful, (*c0mpl3x.Float).Log1p),      // log(1 + x) is summed with guard bits near zero
+	binary("LogBase", Faithful, (*c0mpl3x.Float).LogBase), // the logs are rounded before the quotient
+	unary("Sin", Correct, (*c0mpl3x.Float).Sin),
+	unary("Cos", Correct, (*c0mpl3x.Float).Cos),
+	unary("Tan", Correct, (*c0mpl3x.Float).Tan),
+	binary("Pow", Correct, (*c0mpl3x.Float).Pow),
+	unary("Erf", Faithful, (*c0mpl3x.Float).Erf),           // series and continued fractions with guard bits
+	unary("Erfc", Faithful, (*c0mpl3x.Float).Erfc),         // series and continued fractions with guard bits
+	unary("Erfi", Faithful, (*c0mpl3x.Float).Erfi),         // series and continued fractions with guard bits
+	unary("Faddeeva", Faithful, (*c0mpl3x.Float).Faddeeva), // series and continued fractions with guard bits
+	bessel("BesselJ", 0, (*c0mpl3x.Float).BesselJ),
+	bessel("BesselJ", 1.0/3, (*c0mpl3x.Float).BesselJ),
+	bessel("BesselY", 0, (*c0mpl3x.Float).BesselY),
+	bessel("BesselY", 1.0/3, (*c0mpl3x.Float).BesselY),
+	bessel("BesselI", 1, (*c0mpl3x.Float).BesselI),
+	bessel("BesselK", 1, (*c0mpl3x.Float).BesselK),
+	bessel("HankelH1", .5, (*c0mpl3x.Float).HankelH1),
+	bessel("HankelH2", .5, (*c0mpl3x.Float).HankelH2),
+	lambertW(-1),
+	lambertW(0),
+	lambertW(1),
+}
+
+// newFloat creates a zero imaginary number with the given precision
+func newFloat(prec uint) *c0mpl3x.Float {
+	return c0mpl3x.NewFloat(big.NewFloat(0).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+}
+
+// Random creates a number with the given precision, random mantissas and signs,
+// and exponents in [-scale, scale]
+func Random(rng *rand.Rand, prec uint, scale int) *c0mpl3x.Float {
+	part := func() *big.Float {
+		m := big.NewInt(0).Rand(rng, big.NewInt(0).Lsh(big.NewInt(1), prec))
+		x := big.NewFloat(0).SetPrec(prec).SetInt(m)
+		x.SetMantExp(x, rng.Intn(2*scale+1)-scale-int(prec))
+		if rng.Intn(2) == 0 {
+			x.Neg(x)
+		}
+		return x
+	}
+	return c0mpl3x.NewFloat(part(), part())
+}
+
+// Adversarial creates numbers with the given precision that are hard to evaluate accurately:
+// on and next to the branch cut on the negative real axis, huge and tiny magnitudes,
+// and points next to zeros, poles and branch points of the functions
+func Adversarial(prec uint) []*c0mpl3x.Float {
+	number := func(a, b *big.Float) *c0mpl3x.Float {
+		return c0mpl3x.NewFloat(big.NewFloat(0).SetPrec(prec).Set(a), big.NewFloat(0).SetPrec(prec).Set(b))
+	}
+	tiny := func(e int) *big.Float {
+		return big.NewFloat(0).SetMantExp(big.NewFloat(1), e)
+	}
+	zero, negZero := big.NewFloat(0), big.NewFloat(0).Neg(big.NewFloat(0))
+	one := big.NewFloat(1)
+	pi := big.NewFloat(0).SetPrec(prec).SetFloat64(math.Pi)
+	half := big.NewFloat(0).SetPrec(prec).SetFloat64(math.Pi / 2)
+	e := big.NewFloat(0).SetPrec(prec).SetFloat64(-1 / math.E)
+	ulp := func(x *big.Float) *big.Float {
+		return tiny(x.MantExp(nil) - int(prec))
+	}
+	next := func(x *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(prec).Add(x, ulp(x))
+	}
+
+	numbers := []*c0mpl3x.Float{
+		// the branch cut
+		number(big.NewFloat(-2), zero),
+		number(big.NewFloat(-2), negZero),
+		number(big.NewFloat(-2), tiny(-int(prec))),
+		number(big.NewFloat(-2), big.NewFloat(0).Neg(tiny(-int(prec)))),
+		number(big.NewFloat(-.5), tiny(-3*int(prec))),
+		// huge and tiny magnitudes
+		number(tiny(64), tiny(63)),
+		number(big.NewFloat(0).Neg(tiny(64)), tiny(-64)),
+		number(tiny(-64), tiny(-65)),
+		number(tiny(-int(prec)), big.NewFloat(0).Neg(tiny(-2*int(prec)))),
+		number(big.NewFloat(40), big.NewFloat(-40)),
+		// near one, zero and the branch point -1/e
+		number(next(one), zero),
+		number(one, tiny(-int(prec))),
+		number(big.NewFloat(-1), tiny(-int(prec)/2)),
+		number(e, zero),
+		number(next(e), tiny(-int(prec))),
+		// near the zeros and poles of the trigonometric functions
+		number(pi, zero),
+		number(half, zero),
+		number(pi, tiny(-int(prec))),
+		number(big.NewFloat(0).SetPrec(prec).Mul(pi, big.NewFloat(1<<20)), zero),
+	}
+	return numbers
+}
+
+// ULP computes the error of x in units in the last place of the reference value with the
+// given precision. The error is zero if both values are infinite and infinite if only one
+// of them is or if the reference is zero and x is not
+func ULP(x, reference *big.Float, prec uint) float64 {
+	if x.IsInf() || reference.IsInf() {
+		if x.IsInf() && reference.IsInf() && x.Signbit() == reference.Signbit() {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	d := big.NewFloat(0).SetPrec(x.Prec()+reference.Prec()+64).Sub(x, reference)
+	if d.Sign() == 0 {
+		return 0
+	}
+	if reference.Sign() == 0 {
+		return math.Inf(1)
+	}
+	d.Abs(d)
+	d.SetMantExp(d, int(prec)-reference.MantExp(nil))
+	u, _ := d.Float64()
+	return u
+}
+
+// Error is the error of a function for the arguments X in units in the last place
+type Error struct {
+	X          []*c0mpl3x.Float
+	Real, Imag float64
+}
+
+// Max is the larger of the errors of the real and imaginary parts
+func (e Error) Max() float64 {
+	return math.Max(e.Real, e.Imag)
+}
+
+// Measure evaluates the function for the arguments x with the precision prec and with the
+// reference precision 2prec + 64
+func Measure(f Function, prec uint, x []*c0mpl3x.Float) Error {
+	z := f.Eval(newFloat(prec), x)
+	reference := f.Eval(newFloat(2*prec+64), x)
+	if z.IsNaN() || reference.IsNaN() {
+		if z.IsNaN() && reference.IsNaN() {
+			return Error{X: x}
+		}
+		return Error{X: x, Real: math.Inf(1), Imag: math.Inf(1)}
+	}
+	if z.IsInf() || reference.IsInf() {
+		if z.IsInf() && reference.IsInf() {
+			return Error{X: x}
+		}
+		return Error{X: x, Real: math.Inf(1), Imag: math.Inf(1)}
+	}
+	return Error{
+		X:    x,
+		Real: ULP(z.A, reference.A, prec),
+		Imag: ULP(z.B, reference.B, prec),
+	}
+}
+
+// Report is a summary of the errors of a function
+type Report struct {
+	Function Function
+	Prec     uint
+	Count    int
+	// Mean is the mean of the errors of the parts excluding infinite errors
+	Mean float64
+	// Worst is the largest error
+	Worst Error
+	// Errors are the errors for every argument
+	Errors []Error
+}
+
+// Check measures the errors of the function with the given precision for count random
+// arguments and every combination of the adversarial arguments
+func Check(rng *rand.Rand, f Function, prec uint, count int) Report {
+	report := Report{
+		Function: f,
+		Prec:     prec,
+	}
+	add := func(x []*c0mpl3x.Float) {
+		e := Measure(f, prec, x)
+		report.Errors = append(report.Errors, e)
+		if report.Count == 0 || e.Max() > report.Worst.Max() {
+			report.Worst = e
+		}
+		report.Count++
+	}
+
+	for i := 0; i < count; i++ {
+		var x []*c0mpl3x.Float
+		for j := 0; j < f.Arity; j++ {
+			x = append(x, Random(rng, prec, 8))
+		}
+		add(x)
+	}
+	adversarial := Adversarial(prec)
+	for _, a := range adversarial {
+		x := []*c0mpl3x.Float{a}
+		for j := 1; j < f.Arity; j++ {
+			x = append(x, adversarial[rng.Intn(len(adversarial))])
+		}
+		add(x)
+	}
+
+	sum, n := 0.0, 0
+	for _, e := range report.Errors {
+		for _, u := range []float64{e.Real, e.Imag} {
+			if !math.IsInf(u, 0) {
+				sum += u
+				n++
+			}
+		}
+	}
+	if n > 0 {
+		report.Mean = sum / float64(n)
+	}
+	return report
+}
+
+// String returns a summary of the report
+func (r Report) String() string {
+	s := fmt.Sprintf("%-14s prec %d: %d arguments, mean %.3g ulp, max %.3g ulp", r.Function.Name, r.Prec,
+		r.Count, r.Mean, r.Worst.Max())
+	if r.Worst.Max() > 0 {
+		s += " at"
+		for _, x := range r.Worst.X {
+			s += " (" + x.A.Text('g', 10) + ", " + x.B.Text('g', 10) + ")"
+		}
+	}
+	return s
+}
@@ -0,0 +1,31 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package bigtest
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestULP(t *testing.T) {
+	x := big.NewFloat(1).SetPrec(64)
+	y := big.NewFloat(0).SetMantExp(big.NewFloat(3), -65)
+	y.SetPrec(128).Add(y, x)
+	if u := ULP(y, x, 64); u != .75 {
+		t.Fatal("invalid ulp", u)
+	}
+}
+
+func TestCheck(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, f := range Functions {
+		report := Check(rng, f, 64, 16)
+		t.Log(report.String())
+		if report.Worst.Max() > f.Bound {
+			t.Fatal("error bound exceeded", f.Bound, report.String())
+		}
+	}
+}
@@ -0,0 +1,363 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/rand"
+)
+
+// canonicalPrec is the largest precision used to find the eigenvalues of the Jordan form
+const canonicalPrec = 1 << 14
+
+// integer returns the integer n of the field
+func integer[T any](field Field[T], n int) T {
+	x, one := field.Zero(), field.One()
+	if n < 0 {
+		one, n = field.Neg(one), -n
+	}
+	for i := 0; i < n; i++ {
+		x = field.Add(x, one)
+	}
+	return x
+}
+
+// columnsOf returns the matrix with the vectors as columns and the given number of rows
+func columnsOf[T any](field Field[T], rows int, vectors [][]T) [][]T {
+	values := make([][]T, rows)
+	for i := range values {
+		values[i] = make([]T, 0, len(vectors))
+		for _, v := range vectors {
+			values[i] = append(values[i], v[i])
+		}
+	}
+	return values
+}
+
+// columnVectors returns the columns of a
+func columnVectors[T any](a [][]T) [][]T {
+	if len(a) == 0 {
+		return nil
+	}
+	vectors := make([][]T, len(a[0]))
+	for _, row := range a {
+		for j := range row {
+			vectors[j] = append(vectors[j], row[j])
+		}
+	}
+	return vectors
+}
+
+// apply computes a v
+func apply[T any](field Field[T], a [][]T, v []T) []T {
+	y := make([]T, len(a))
+	for i, row := range a {
+		y[i] = field.Zero()
+		for j := range row {
+			y[i] = field.Add(y[i], field.Mul(row[j], v[j]))
+		}
+	}
+	return y
+}
+
+// rank computes the rank of the vectors
+func rank[T any](field Field[T], n int, vectors [][]T) int {
+	return len(rref(field, columnsOf(field, n, vectors)))
+}
+
+// companion sets the block of the block diagonal matrix f at the offset to the companion matrix
+// of the monic polynomial p, with ones below the diagonal and the last column -c0, ..., -c(n-1)
+// https://en.wikipedia.org/wiki/Companion_matrix
+func companion[T any](field Field[T], f [][]T, offset int, p *Polynomial[T]) {
+	n := p.Degree()
+	for i := 0; i < n; i++ {
+		if i > 0 {
+			f[offset+i][offset+i-1] = field.One()
+		}
+		f[offset+i][offset+n-1] = field.Neg(p.Coefficients[i])
+	}
+}
+
+// frobenius computes the invariant factors f1, f2, ... of a with f(i+1) | fi and the bases
+// of the cyclic subspaces of the invariant factors
+func frobenius[T any](field Field[T], a [][]T, rng *rand.Rand) ([]Polynomial[T], [][][]T) {
+	n := len(a)
+	if n == 0 {
+		return nil, nil
+	}
+	d := Dense[T]{Field: field, Values: a}
+	minimal := d.MinPoly()
+	degree := minimal.Degree()
+
+	// the vector v with the minimal polynomial of the matrix generates the cyclic subspace
+	// v, av, ..., a^(d-1)v of the largest dimension
+	var krylov [][]T
+	for attempt := 0; ; attempt++ {
+		// the unit vectors and then random vectors with small entries
+		v := make([]T, n)
+		for i := range v {
+			v[i] = field.Zero()
+			if attempt >= n {
+				v[i] = integer(field, rng.Intn(2*n+1)-n)
+			}
+		}
+		if attempt < n {
+			v[attempt] = field.One()
+		}
+		krylov = [][]T{v}
+		for len(krylov) < degree {
+			krylov = append(krylov, apply(field, a, krylov[len(krylov)-1]))
+		}
+		if rank(field, n, krylov) == degree {
+			break
+		}
+	}
+
+	// the functional w with w a^i v = 0 for i < d - 1 and w a^(d-1) v = 1 gives the invariant
+	// complement, the vectors x with w a^i x = 0 for i < d
+	augmented := make([][]T, degree)
+	for i := range augmented {
+		augmented[i] = append(append([]T{}, krylov[i]...), field.Zero())
+	}
+	augmented[degree-1][n] = field.One()
+	pivots := rref(field, augmented)
+	w := make([]T, n)
+	for i := range w {
+		w[i] = field.Zero()
+	}
+	for i, p := range pivots {
+		w[p] = augmented[i][n]
+	}
+	functionals := Dense[T]{Field: field, Values: [][]T{w}}
+	for i := 1; i < degree; i++ {
+		row := make([]T, n)
+		for j := range row {
+			row[j] = field.Zero()
+			for k := range w {
+				row[j] = field.Add(row[j], field.Mul(functionals.Values[i-1][k], a[k][j]))
+			}
+		}
+		functionals.Values = append(functionals.Values, row)
+	}
+	complement := Dense[T]{}
+	complement.NullSpace(&functionals)
+	basis := columnVectors(complement.Values)
+	m := len(basis)
+
+	// the restriction x of a to the complement with a b = b x
+	restriction := make([][]T, n)
+	image := make([][]T, m)
+	for j := range basis {
+		image[j] = apply(field, a, basis[j])
+	}
+	for i := range restriction {
+		for j := range basis {
+			restriction[i] = append(restriction[i], basis[j][i])
+		}
+		for j := range image {
+			restriction[i] = append(restriction[i], image[j][i])
+		}
+	}
+	rref(field, restriction)
+	x := make([][]T, m)
+	for i := range x {
+		x[i] = restriction[i][m:]
+	}
+
+	factors, bases := frobenius(field, x, rng)
+	for k := range bases {
+		for j, v := range bases[k] {
+			bases[k][j] = apply(field, complement.Values, v)
+		}
+	}
+	return append([]Polynomial[T]{minimal}, factors...), append([][][]T{krylov}, bases...)
+}
+
+// Frobenius sets d to the Frobenius normal form of the square matrix a, the block diagonal
+// matrix of the companion matrices of the invariant factors f1 | f2 | ... | fk, and returns d
+// and the invertible matrix p with a = p d p^-1, the columns of p are the bases v, av, ... of
+// the cyclic subspaces of the invariant factors
+// https://en.wikipedia.org/wiki/Frobenius_normal_form
+func (d *Dense[T]) Frobenius(a *Dense[T]) (*Dense[T], *Dense[T]) {
+	a.square()
+	field, n := a.Field, len(a.Values)
+	factors, bases := frobenius(field, a.copy(), rand.New(rand.NewSource(1)))
+	f := NewDense(field, n, n)
+	var vectors [][]T
+	offset := 0
+	for i := len(factors) - 1; i >= 0; i-- {
+		companion(field, f.Values, offset, &factors[i])
+		offset += factors[i].Degree()
+		vectors = append(vectors, bases[i]...)
+	}
+	p := Dense[T]{Field: field, Values: columnsOf(field, n, vectors)}
+	d.Field, d.Values = field, f.Values
+	return d, &p
+}
+
+// gaussianRound rounds x to the nearest integer
+func gaussianRound(x *big.Float) *big.Int {
+	y := big.NewFloat(0).SetPrec(x.Prec()+1).Add(x, big.NewFloat(.5))
+	i, acc := y.Int(nil)
+	if acc == big.Above {
+		i.Sub(i, big.NewInt(1))
+	}
+	return i
+}
+
+// rationalRoots computes the roots in Q(i) of the square-free monic polynomial f, ok is false
+// if a root of f isn't in Q(i). The roots r of f are in Z[i]/D for the least common multiple D
+// of the denominators of f, they are found by rounding the certified roots of f and verified
+// exactly
+func rationalRoots(f *Polynomial[*Rational]) (roots []*Rational, ok bool) {
+	if f.Degree() < 1 {
+		return nil, true
+	}
+	denominator := big.NewInt(1)
+	bits := 0
+	for _, c := range f.Coefficients {
+		for _, x := range []*big.Rat{c.A, c.B} {
+			g := big.NewInt(0).GCD(nil, nil, denominator, x.Denom())
+			denominator.Mul(denominator, big.NewInt(0).Quo(x.Denom(), g))
+			if b := x.Num().BitLen() + x.Denom().BitLen(); b > bits {
+				bits = b
+			}
+		}
+	}
+	scale := big.NewFloat(0).SetInt(denominator)
+	for prec := uint(64 + 2*bits + denominator.BitLen()); prec <= canonicalPrec; prec *= 2 {
+		c := NewContext(prec, big.ToNearestEven)
+		p := c.NewPolynomial(f)
+		balls := Roots(&p, prec)
+		// the balls scaled by D contain at most one point of Z[i]
+		bound := big.NewFloat(0x1p-16)
+		tight := true
+		for _, b := range balls {
+			if big.NewFloat(0).Mul(b.R, scale).Cmp(bound) > 0 {
+				tight = false
+				break
+			}
+		}
+		if !tight {
+			continue
+		}
+		roots = roots[:0]
+		for _, b := range balls {
+			x := big.NewFloat(0).SetPrec(prec).Mul(b.M.A, scale)
+			y := big.NewFloat(0).SetPrec(prec).Mul(b.M.B, scale)
+			r := NewRational(big.NewRat(0, 1).SetFrac(gaussianRound(x), denominator),
+				big.NewRat(0, 1).SetFrac(gaussianRound(y), denominator))
+			if !(RationalField{}).IsZero(f.Eval(r)) {
+				return nil, false
+			}
+			roots = append(roots, r)
+		}
+		return roots, true
+	}
+	return nil, false
+}
+
+// Jordan sets m to the Jordan normal form of the square matrix a over Q(i) and returns m and
+// the invertible matrix p with a = p m p^-1, the columns of p are the Jordan chains
+// (a - l)^(k-1) v, ..., (a - l) v, v of the eigenvalues l. ok is false and m is unchanged if
+// an eigenvalue of a isn't in Q(i). The eigenvalues are the roots of the square-free factors
+// of the characteristic polynomial
+// https://en.wikipedia.org/wiki/Jordan_normal_form
+func (m *Matrix) Jordan(a *Matrix) (j *Matrix, p *Matrix, ok bool) {
+	d := a.Dense()
+	d.square()
+	field, n := d.Field, len(d.Values)
+	characteristic := d.CharPoly()
+	type eigenvalue struct {
+		value        *Rational
+		multiplicity int
+	}
+	var eigenvalues []eigenvalue
+	if n > 0 {
+		_, factors := characteristic.SquareFree()
+		for i := range factors {
+			roots, ok := rationalRoots(&factors[i])
+			if !ok {
+				return m, nil, false
+			}
+			for _, r := range roots {
+				eigenvalues = append(eigenvalues, eigenvalue{r, i + 1})
+			}
+		}
+	}
+
+	jordan := NewDense[*Rational](field, n, n)
+	var vectors [][]*Rational
+	for _, e := range eigenvalues {
+		// the powers of a - l up to the power with a null space of the dimension of the multiplicity
+		shifted := Dense[*Rational]{Field: field, Values: d.copy()}
+		for i := 0; i < n; i++ {
+			shifted.Values[i][i] = field.Add(shifted.Values[i][i], field.Neg(e.value))
+		}
+		kernels := [][][]*Rational{nil}
+		power := Identity[*Rational](field, n)
+		for len(kernels[len(kernels)-1]) < e.multiplicity {
+			power.Mul(&power, &shifted)
+			kernel := Dense[*Rational]{}
+			kernel.NullSpace(&power)
+			kernels = append(kernels, columnVectors(kernel.Values))
+		}
+
+		// the chains start at the vectors of the kernel of the power k that are independent of
+		// the kernel of the power k - 1 and the vectors of the longer chains at level k
+		type chain struct {
+			top    []*Rational
+			length int
+		}
+		var chains []chain
+		for k := len(kernels) - 1; k > 0; k-- {
+			span := append([][]*Rational{}, kernels[k-1]...)
+			for _, c := range chains {
+				v := c.top
+				for i := k; i < c.length; i++ {
+					v = apply(field, shifted.Values, v)
+				}
+				span = append(span, v)
+			}
+			r := rank(field, n, span)
+			for _, u := range kernels[k] {
+				if rank(field, n, append(span, u)) > r {
+					span = append(span, u)
+					r++
+					chains = append(chains, chain{u, k})
+				}
+			}
+		}
+		for _, c := range chains {
+			vector := make([][]*Rational, c.length)
+			vector[c.length-1] = c.top
+			for i := c.length - 2; i >= 0; i-- {
+				vector[i] = apply(field, shifted.Values, vector[i+1])
+			}
+			offset := len(vectors)
+			for i := range vector {
+				jordan.Values[offset+i][offset+i] = field.Add(field.Zero(), e.value)
+				if i > 0 {
+					jordan.Values[offset+i-1][offset+i] = field.One()
+				}
+			}
+			vectors = append(vectors, vector...)
+		}
+	}
+	transform := Dense[*Rational]{Field: field, Values: columnsOf[*Rational](field, n, vectors)}
+	p = &Matrix{Prec: m.Prec, Mode: m.Mode}
+	p.SetDense(&transform)
+	return m.SetDense(&jordan), p, true
+}
+
+// Frobenius sets m to the exact Frobenius normal form of the square matrix a and returns m and
+// the invertible matrix p with a = p m p^-1
+func (m *Matrix) Frobenius(a *Matrix) (*Matrix, *Matrix) {
+	d := a.Dense()
+	f, transform := d.Frobenius(&d)
+	p := &Matrix{Prec: m.Prec, Mode: m.Mode}
+	p.SetDense(transform)
+	return m.SetDense(f), p
+}
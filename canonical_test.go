@@ -0,0 +1,129 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// similar determines if ap = pj for an invertible p
+func similar(a, p, j *Matrix) bool {
+	x, y := NewMatrix(64), NewMatrix(64)
+	x.Mul(a, p)
+	y.Mul(p, j)
+	d := p.Dense()
+	det := d.Det()
+	return x.String() == y.String() && !(RationalField{}).IsZero(det)
+}
+
+func TestMatrix_Jordan(t *testing.T) {
+	// a = q j q^-1 for the Jordan blocks of 2 and 3i
+	j := rationalMatrix(64, 1,
+		[]int64{2, 0, 1, 0, 0, 0, 0, 0},
+		[]int64{0, 0, 2, 0, 0, 0, 0, 0},
+		[]int64{0, 0, 0, 0, 0, 3, 1, 0},
+		[]int64{0, 0, 0, 0, 0, 0, 0, 3},
+	)
+	q := rationalMatrix(64, 1,
+		[]int64{1, 0, 2, 0, 0, 1, 1, 0},
+		[]int64{0, 0, 1, 0, 3, 0, 0, 0},
+		[]int64{1, 1, 0, 0, 1, 0, 2, 0},
+		[]int64{0, 0, 0, 0, 1, 0, 1, 0},
+	)
+	d := q.Dense()
+	inv := Dense[*Rational]{}
+	inv.Inv(&d)
+	qinv := NewMatrix(64)
+	qinv.SetDense(&inv)
+	a := NewMatrix(64)
+	a.Mul(&q, &j)
+	a.Mul(&a, &qinv)
+
+	jordan := NewMatrix(64)
+	_, p, ok := jordan.Jordan(&a)
+	if !ok {
+		t.Fatal("the eigenvalues are rational")
+	}
+	if jordan.String() != "[0 + 3i 1 0 0;0 0 + 3i 0 0;0 0 2 1;0 0 0 2]" {
+		t.Fatal("invalid Jordan form", jordan.String())
+	}
+	if !similar(&a, p, &jordan) {
+		t.Fatal("invalid transformation", p.String())
+	}
+
+	// the eigenvalues of [[0 2];[1 0]] are sqrt(2) and -sqrt(2)
+	b := rationalMatrix(64, 1, []int64{0, 0, 2, 0}, []int64{1, 0, 0, 0})
+	if _, _, ok := jordan.Jordan(&b); ok {
+		t.Fatal("the eigenvalues aren't rational")
+	}
+
+	// a nilpotent matrix with the chains of lengths 2 and 1
+	c := rationalMatrix(64, 1,
+		[]int64{0, 0, 1, 0, 1, 0},
+		[]int64{0, 0, 0, 0, 0, 0},
+		[]int64{0, 0, 0, 0, 0, 0},
+	)
+	_, p, ok = jordan.Jordan(&c)
+	if !ok || jordan.String() != "[0 1 0;0 0 0;0 0 0]" || !similar(&c, p, &jordan) {
+		t.Fatal("invalid Jordan form", jordan.String())
+	}
+}
+
+func TestMatrix_Frobenius(t *testing.T) {
+	a := rationalMatrix(64, 1,
+		[]int64{1, 0, 0, 0, 0, 0},
+		[]int64{0, 0, 1, 0, 0, 0},
+		[]int64{0, 0, 0, 0, 2, 0},
+	)
+	f := NewMatrix(64)
+	_, p := f.Frobenius(&a)
+	if f.String() != "[1 0 0;0 0 -2;0 1 3]" {
+		t.Fatal("invalid Frobenius form", f.String())
+	}
+	if !similar(&a, p, &f) {
+		t.Fatal("invalid transformation", p.String())
+	}
+
+	// the Frobenius form of a companion matrix is the matrix
+	b := rationalMatrix(64, 1,
+		[]int64{0, 0, 0, 0, 5, 1},
+		[]int64{1, 0, 0, 0, -2, 0},
+		[]int64{0, 0, 1, 0, 0, 3},
+	)
+	_, p = f.Frobenius(&b)
+	if f.String() != b.String() || !similar(&b, p, &f) {
+		t.Fatal("invalid Frobenius form", f.String())
+	}
+
+	c := rationalMatrix(64, 1,
+		[]int64{2, 1, 0, 0, 1, 1, 1, 0},
+		[]int64{1, 3, 2, 0, 4, -1, 0, 2},
+		[]int64{0, 1, 5, 0, 2, 0, -3, 0},
+		[]int64{7, 0, 0, 0, 1, 1, 2, -2},
+	)
+	_, p = f.Frobenius(&c)
+	if !similar(&c, p, &f) {
+		t.Fatal("invalid transformation", p.String())
+	}
+}
+
+func TestDense_Frobenius(t *testing.T) {
+	// over GF(3) the invariant factors are x - 2 and (x - 2)^2 = x^2 + 2x + 1
+	field := NewModularField(big.NewInt(3))
+	n := big.NewInt
+	a := Dense[*big.Int]{Field: field, Values: [][]*big.Int{{n(2), n(0), n(0)}, {n(0), n(2), n(1)}, {n(0), n(0), n(2)}}}
+	f := Dense[*big.Int]{}
+	_, p := f.Frobenius(&a)
+	if f.String() != "[2 0 0;0 0 2;0 1 1]" {
+		t.Fatal("invalid Frobenius form", f.String())
+	}
+	x, y := Dense[*big.Int]{}, Dense[*big.Int]{}
+	x.Mul(&a, p)
+	y.Mul(p, &f)
+	if x.String() != y.String() || field.IsZero(p.Det()) {
+		t.Fatal("invalid transformation", p.String())
+	}
+}
@@ -0,0 +1,185 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+// square panics if d isn't a square matrix
+func (d *Dense[T]) square() {
+	for _, row := range d.Values {
+		if len(row) != len(d.Values) {
+			panic("non square matrix")
+		}
+	}
+}
+
+// berkowitz computes the coefficients of det(xI - a) in decreasing order of degree without
+// division, a = [[s, r], [c, b]] gives the coefficients as the product of a Toeplitz matrix
+// with the first column 1, -s, -rc, -rbc, -rb^2c, ... and the coefficients of b
+// https://en.wikipedia.org/wiki/Samuelson%E2%80%93Berkowitz_algorithm
+func berkowitz[T any](field Field[T], a [][]T) []T {
+	n := len(a)
+	if n == 0 {
+		return []T{field.One()}
+	}
+	b := make([][]T, n-1)
+	for i := range b {
+		b[i] = a[i+1][1:]
+	}
+	column := []T{field.One(), field.Neg(a[0][0])}
+	c := make([]T, n-1)
+	for i := range c {
+		c[i] = a[i+1][0]
+	}
+	for k := 0; k < n-1; k++ {
+		// -r b^k c
+		sum := field.Zero()
+		for i := range c {
+			sum = field.Add(sum, field.Mul(a[0][i+1], c[i]))
+		}
+		column = append(column, field.Neg(sum))
+		next := make([]T, n-1)
+		for i := range next {
+			next[i] = field.Zero()
+			for j := range c {
+				next[i] = field.Add(next[i], field.Mul(b[i][j], c[j]))
+			}
+		}
+		c = next
+	}
+	coefficients := berkowitz(field, b)
+	values := make([]T, n+1)
+	for i := range values {
+		values[i] = field.Zero()
+		for j := 0; j <= i && j < len(coefficients); j++ {
+			values[i] = field.Add(values[i], field.Mul(column[i-j], coefficients[j]))
+		}
+	}
+	return values
+}
+
+// CharPoly computes the characteristic polynomial det(xI - d) of a square matrix with the
+// division free Berkowitz algorithm
+// https://en.wikipedia.org/wiki/Characteristic_polynomial
+func (d *Dense[T]) CharPoly() Polynomial[T] {
+	d.square()
+	values := berkowitz(d.Field, d.Values)
+	for i, j := 0, len(values)-1; i < j; i, j = i+1, j-1 {
+		values[i], values[j] = values[j], values[i]
+	}
+	p := Polynomial[T]{}
+	p.set(d.Field, values)
+	return p
+}
+
+// MinPoly computes the minimal polynomial of a square matrix, the monic polynomial p of the
+// least degree with p(d) = 0, from the first linear dependency of the powers of d
+// https://en.wikipedia.org/wiki/Minimal_polynomial_(linear_algebra)
+func (d *Dense[T]) MinPoly() Polynomial[T] {
+	d.square()
+	field, n := d.Field, len(d.Values)
+	type reduced struct {
+		vector       []T
+		pivot        int
+		coefficients []T
+	}
+	var basis []reduced
+	power := Identity(field, n)
+	for k := 0; ; k++ {
+		var vector []T
+		for _, row := range power.Values {
+			vector = append(vector, row...)
+		}
+		// the coefficients of the combination of the powers that is the reduced vector
+		coefficients := make([]T, k+1)
+		for i := range coefficients {
+			coefficients[i] = field.Zero()
+		}
+		coefficients[k] = field.One()
+		for _, b := range basis {
+			if field.IsZero(vector[b.pivot]) {
+				continue
+			}
+			factor := field.Neg(field.Mul(vector[b.pivot], field.Inv(b.vector[b.pivot])))
+			for i := range vector {
+				vector[i] = field.Add(vector[i], field.Mul(factor, b.vector[i]))
+			}
+			for i := range b.coefficients {
+				coefficients[i] = field.Add(coefficients[i], field.Mul(factor, b.coefficients[i]))
+			}
+		}
+		pivot := -1
+		for i := range vector {
+			if !field.IsZero(vector[i]) {
+				pivot = i
+				break
+			}
+		}
+		if pivot < 0 {
+			p := Polynomial[T]{}
+			p.set(field, coefficients)
+			return p
+		}
+		basis = append(basis, reduced{vector, pivot, coefficients})
+		power.Mul(&power, d)
+	}
+}
+
+// Eval computes p(a) for a square matrix a with Horner's method
+// https://en.wikipedia.org/wiki/Matrix_polynomial
+func (d *Dense[T]) Eval(p *Polynomial[T], a *Dense[T]) *Dense[T] {
+	a.square()
+	field, n := a.Field, len(a.Values)
+	y := NewDense(field, n, n)
+	for i := len(p.Coefficients) - 1; i >= 0; i-- {
+		if i < len(p.Coefficients)-1 {
+			y.Mul(&y, a)
+		}
+		for j := 0; j < n; j++ {
+			y.Values[j][j] = field.Add(y.Values[j][j], p.Coefficients[i])
+		}
+	}
+	d.Field, d.Values = field, y.Values
+	return d
+}
+
+// CayleyHamilton determines if the matrix satisfies its characteristic polynomial, p(d) = 0
+// https://en.wikipedia.org/wiki/Cayley%E2%80%93Hamilton_theorem
+func (d *Dense[T]) CayleyHamilton() bool {
+	p := d.CharPoly()
+	y := Dense[T]{}
+	y.Eval(&p, d)
+	for _, row := range y.Values {
+		for _, x := range row {
+			if !d.Field.IsZero(x) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// CharPoly computes the exact characteristic polynomial det(xI - m) of a square matrix
+func (m *Matrix) CharPoly() Polynomial[*Rational] {
+	d := m.Dense()
+	return d.CharPoly()
+}
+
+// MinPoly computes the exact minimal polynomial of a square matrix
+func (m *Matrix) MinPoly() Polynomial[*Rational] {
+	d := m.Dense()
+	return d.MinPoly()
+}
+
+// Eval computes p(a) exactly for a square matrix a
+func (m *Matrix) Eval(p *Polynomial[*Rational], a *Matrix) *Matrix {
+	d := a.Dense()
+	d.Eval(p, &d)
+	return m.SetDense(&d)
+}
+
+// CayleyHamilton determines if the matrix satisfies its characteristic polynomial exactly
+func (m *Matrix) CayleyHamilton() bool {
+	d := m.Dense()
+	return d.CayleyHamilton()
+}
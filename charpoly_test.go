@@ -0,0 +1,89 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestMatrix_CharPoly(t *testing.T) {
+	a := rationalMatrix(64, 1,
+		[]int64{2, 0, 1, 0, 0, 0},
+		[]int64{0, 0, 2, 0, 0, 0},
+		[]int64{0, 0, 0, 0, 2, 0},
+	)
+	if p := a.CharPoly(); p.String() != "(-8/1 + 0/1i) + (12/1 + 0/1i)x + (-6/1 + 0/1i)x^2 + (1/1 + 0/1i)x^3" {
+		t.Fatal("invalid characteristic polynomial", p.String())
+	}
+	if p := a.MinPoly(); p.String() != "(4/1 + 0/1i) + (-4/1 + 0/1i)x + (1/1 + 0/1i)x^2" {
+		t.Fatal("invalid minimal polynomial", p.String())
+	}
+
+	b := rationalMatrix(64, 1,
+		[]int64{0, 0, -1, 0},
+		[]int64{1, 0, 0, 0},
+	)
+	if p := b.CharPoly(); p.String() != "(1/1 + 0/1i) + (1/1 + 0/1i)x^2" {
+		t.Fatal("invalid characteristic polynomial", p.String())
+	}
+	if p := b.MinPoly(); p.String() != "(1/1 + 0/1i) + (1/1 + 0/1i)x^2" {
+		t.Fatal("invalid minimal polynomial", p.String())
+	}
+
+	c := rationalMatrix(64, 1,
+		[]int64{2, 1, 0, 0, 1, 1, 1, 0},
+		[]int64{1, 3, 0, 0, 4, -1, 0, 2},
+		[]int64{0, 1, 5, 0, 1, 0, -3, 0},
+		[]int64{7, 0, 0, 0, 1, 1, 2, -2},
+	)
+	p := c.CharPoly()
+	if p.Degree() != 4 || p.Lead().String() != "1/1 + 0/1i" {
+		t.Fatal("invalid characteristic polynomial", p.String())
+	}
+	// p(0) = det(-c) = det(c) for even n
+	d := c.Dense()
+	if det := d.Det(); p.Coefficients[0].String() != det.String() {
+		t.Fatal("invalid constant term", p.Coefficients[0].String(), det.String())
+	}
+	// the trace is minus the coefficient of x^3
+	if p.Coefficients[3].String() != "-5/1 + 1/1i" {
+		t.Fatal("invalid trace", p.Coefficients[3].String())
+	}
+	if q := c.MinPoly(); q.String() != p.String() {
+		t.Fatal("invalid minimal polynomial", q.String())
+	}
+	for _, m := range []Matrix{a, b, c} {
+		if !m.CayleyHamilton() {
+			t.Fatal("invalid Cayley-Hamilton check", m.String())
+		}
+	}
+
+	// the minimal polynomial divides the characteristic polynomial
+	characteristic, minimal := a.CharPoly(), a.MinPoly()
+	q, r := (&Polynomial[*Rational]{}).QuoRem(&characteristic, &minimal)
+	if q.String() != "(-2/1 + 0/1i) + (1/1 + 0/1i)x" || !r.IsZero() {
+		t.Fatal("invalid division", q.String(), r.String())
+	}
+
+	e := NewMatrix(64)
+	e.Eval(&minimal, &a)
+	if e.String() != "[0 0 0;0 0 0;0 0 0]" {
+		t.Fatal("invalid evaluation", e.String())
+	}
+}
+
+func TestDense_CharPoly(t *testing.T) {
+	// over GF(5) the matrix [[1 2];[3 4]] has the characteristic polynomial x^2 - 5x - 2 = x^2 + 3
+	field := NewModularField(big.NewInt(5))
+	n := big.NewInt
+	d := Dense[*big.Int]{Field: field, Values: [][]*big.Int{{n(1), n(2)}, {n(3), n(4)}}}
+	if p := d.CharPoly(); p.String() != "(3) + (1)x^2" {
+		t.Fatal("invalid characteristic polynomial", p.String())
+	}
+	if !d.CayleyHamilton() {
+		t.Fatal("invalid Cayley-Hamilton check")
+	}
+}
@@ -5,14 +5,16 @@
 package big
 
 import (
+	"math"
 	"math/big"
 
 	"github.com/ALTree/bigfloat"
 )
 
-// Matrix is a matrix
+// Matrix is a matrix, the receiver of a method may alias the operands, which are never changed
 type Matrix struct {
 	Prec   uint
+	Mode   big.RoundingMode
 	Values [][]Rational
 }
 
@@ -23,6 +25,11 @@ func NewMatrix(prec uint) Matrix {
 	}
 }
 
+// singular determines if m is 1x1
+func (m *Matrix) singular() bool {
+	return len(m.Values) == 1 && len(m.Values[0]) == 1
+}
+
 // Add adds two matricies
 func (m *Matrix) Add(a, b *Matrix) *Matrix {
 	asingular := len(a.Values) == 1 && len(a.Values[0]) == 1
@@ -109,41 +116,9 @@ func (m *Matrix) Sub(a, b *Matrix) *Matrix {
 
 // Mul multiplies two matricies
 func (m *Matrix) Mul(a, b *Matrix) *Matrix {
-	asingular := len(a.Values) == 1 && len(a.Values[0]) == 1
-	bsingular := len(b.Values) == 1 && len(b.Values[0]) == 1
-	if asingular || bsingular {
-		if bsingular {
-			a, b = b, a
-		}
-		value, values := a.Values[0][0], [][]Rational{}
-		for _, b := range b.Values {
-			var row []Rational
-			for _, bb := range b {
-				ab := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
-				row = append(row, *(ab.Mul(&bb, &value)))
-			}
-			values = append(values, row)
-		}
-		m.Values = values
-		return m
-	}
-
-	values := [][]Rational{}
-	for x := 0; x < len(a.Values); x++ {
-		var row []Rational
-		for y := 0; y < len(b.Values[0]); y++ {
-			sum := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
-			for z := 0; z < len(b.Values); z++ {
-				ab := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
-				ab.Mul(&a.Values[x][z], &b.Values[z][y])
-				sum.Add(sum, ab)
-			}
-			row = append(row, *sum)
-		}
-		values = append(values, row)
-	}
-	m.Values = values
-	return m
+	x, y := a.Dense(), b.Dense()
+	x.Mul(&x, &y)
+	return m.SetDense(&x)
 }
 
 // Div divides two matricies
@@ -162,12 +137,13 @@ func (m *Matrix) Div(a, b *Matrix) *Matrix {
 	panic("can't divide non 1x1 matrices")
 }
 
+// apply applies the function to copies of the entries of a, so a isn't changed
 func (m *Matrix) apply(a *Matrix, function func(a *Rational) *Rational) *Matrix {
 	values := [][]Rational{}
 	for _, a := range a.Values {
 		var row []Rational
 		for _, aa := range a {
-			row = append(row, *(function(&aa)))
+			row = append(row, *(function(aa.copy())))
 		}
 		values = append(values, row)
 	}
@@ -175,12 +151,13 @@ func (m *Matrix) apply(a *Matrix, function func(a *Rational) *Rational) *Matrix
 	return m
 }
 
+// apply2 applies the function to copies of the entries of a and y
 func (m *Matrix) apply2(a *Matrix, y *Rational, function func(a *Rational, b *Rational) *Rational) *Matrix {
 	values := [][]Rational{}
 	for _, a := range a.Values {
 		var row []Rational
 		for _, aa := range a {
-			row = append(row, *(function(&aa, y)))
+			row = append(row, *(function(aa.copy(), y.copy())))
 		}
 		values = append(values, row)
 	}
@@ -191,7 +168,7 @@ func (m *Matrix) apply2(a *Matrix, y *Rational, function func(a *Rational, b *Ra
 // Abs computes the absolute value of the entries of the matrix
 func (m *Matrix) Abs(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Abs(x).Rat(a)
 		return a
@@ -201,7 +178,7 @@ func (m *Matrix) Abs(a *Matrix) *Matrix {
 // Conj computes the complex conjugate of a
 func (m *Matrix) Conj(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Conj(x).Rat(a)
 		return a
@@ -211,7 +188,7 @@ func (m *Matrix) Conj(a *Matrix) *Matrix {
 // Sqrt computes the square root of the matrix
 func (m *Matrix) Sqrt(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Sqrt(x).Rat(a)
 		return a
@@ -222,7 +199,7 @@ func (m *Matrix) Sqrt(a *Matrix) *Matrix {
 // https://en.wikipedia.org/wiki/Atan2
 func (m *Matrix) Atan2(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Atan2(x).Rat(a)
 		return a
@@ -233,7 +210,7 @@ func (m *Matrix) Atan2(a *Matrix) *Matrix {
 // https://mathworld.wolfram.com/ComplexArgument.html
 func (m *Matrix) Arg(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Arg(x).Rat(a)
 		return a
@@ -244,7 +221,7 @@ func (m *Matrix) Arg(a *Matrix) *Matrix {
 // https://www.wolframalpha.com/input/?i=e%5E%28x+%2B+yi%29
 func (m *Matrix) Exp(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Exp(x).Rat(a)
 		return a
@@ -255,7 +232,7 @@ func (m *Matrix) Exp(a *Matrix) *Matrix {
 // https://www.wolframalpha.com/input/?i=cos%28x+%2B+yi%29
 func (m *Matrix) Cos(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Cos(x).Rat(a)
 		return a
@@ -266,7 +243,7 @@ func (m *Matrix) Cos(a *Matrix) *Matrix {
 // https://www.wolframalpha.com/input/?i=sin%28x+%2B+yi%29
 func (m *Matrix) Sin(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Sin(x).Rat(a)
 		return a
@@ -277,7 +254,7 @@ func (m *Matrix) Sin(a *Matrix) *Matrix {
 // https://en.wikipedia.org/wiki/Trigonometric_functions
 func (m *Matrix) Tan(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Tan(x).Rat(a)
 		return a
@@ -288,7 +265,7 @@ func (m *Matrix) Tan(a *Matrix) *Matrix {
 // https://en.wikipedia.org/wiki/Complex_logarithm
 func (m *Matrix) Log(a *Matrix) *Matrix {
 	return m.apply(a, func(a *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
 		x.Log(x).Rat(a)
 		return a
@@ -299,9 +276,9 @@ func (m *Matrix) Log(a *Matrix) *Matrix {
 // https://mathworld.wolfram.com/ComplexExponentiation.html
 func (m *Matrix) Pow(x *Matrix, y *Rational) *Matrix {
 	return m.apply2(x, y, func(a, b *Rational) *Rational {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(a)
-		y := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		y := m.Context().NewFloat(nil, nil)
 		y.SetRat(b)
 		x.Pow(x, y).Rat(a)
 		return a
@@ -318,7 +295,7 @@ func (m *Matrix) Neg(a *Matrix) *Matrix {
 
 func (m *Matrix) String() string {
 	if len(m.Values) == 1 && len(m.Values[0]) == 1 {
-		x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+		x := m.Context().NewFloat(nil, nil)
 		x.SetRat(&m.Values[0][0])
 		return x.String()
 	}
@@ -327,7 +304,7 @@ func (m *Matrix) String() string {
 	for i, row := range m.Values {
 		lastColumn := len(row) - 1
 		for j, value := range row {
-			x := NewFloat(big.NewFloat(0).SetPrec(m.Prec), big.NewFloat(0).SetPrec(m.Prec))
+			x := m.Context().NewFloat(nil, nil)
 			x.SetRat(&value)
 			s += x.String()
 			if j < lastColumn {
@@ -341,9 +318,11 @@ func (m *Matrix) String() string {
 	return s + "]"
 }
 
-// Float is an imaginary number
+// Float is an imaginary number, the receiver of a method may alias the operands, which are never changed
 type Float struct {
-	A, B *big.Float
+	A, B      *big.Float
+	nan       bool
+	uncertain bool
 }
 
 // NewFloat creates a new imaginary number
@@ -354,304 +333,1111 @@ func NewFloat(a, b *big.Float) *Float {
 	}
 }
 
+// newFloat creates a zero imaginary number with the given precision
+func newFloat(prec uint) *Float {
+	return NewFloat(big.NewFloat(0).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+}
+
+// clone copies a into a new imaginary number with the given precision
+func (f *Float) clone(prec uint) *Float {
+	return NewFloat(big.NewFloat(0).SetPrec(prec).Set(f.A), big.NewFloat(0).SetPrec(prec).Set(f.B))
+}
+
+// norm computes the squared absolute value of a
+func norm(a *Float) *big.Float {
+	x := big.NewFloat(0).SetPrec(a.A.Prec())
+	y := big.NewFloat(0).SetPrec(a.A.Prec())
+	x.Mul(a.A, a.A)
+	y.Mul(a.B, a.B)
+	return x.Add(x, y)
+}
+
+// inv computes the reciprocal 1/a = conj(a)/|a|^2
+func (f *Float) inv(a *Float) *Float {
+	n := norm(a)
+	f.A.Quo(a.A, n)
+	f.B.Quo(a.B, n)
+	f.B.Neg(f.B)
+	return f
+}
+
+// set rounds the real and imaginary parts of x into f, keeping the parts of the
+// result that are zero by symmetry exactly zero
+func (f *Float) set(x *Float, realZero, imagZero bool) *Float {
+	if realZero {
+		f.A.SetInt64(0)
+	} else {
+		f.A.Set(x.A)
+	}
+	if imagZero {
+		f.B.SetInt64(0)
+	} else {
+		f.B.Set(x.B)
+	}
+	return f
+}
+
+// floatGuard is the number of guard bits used by the elementary functions
+const floatGuard = 32
+
 // Abs computes the absolute value of a
 func (f *Float) Abs(a *Float) *Float {
-	f.A.Mul(a.A, a.A)
-	f.B.Mul(a.B, a.B)
-	f.A = bigfloat.Sqrt(f.A.Add(f.A, f.B))
-	f.B = big.NewFloat(0)
-	return f
+	return f.unary(a, infinite, func() *Float {
+		f.A.Set(modulus(a, f.A.Prec()+floatGuard))
+		f.B.SetInt64(0)
+		return f
+	})
+}
+
+// modulus computes |a| with the given precision
+func modulus(a *Float, prec uint) *big.Float {
+	l := norm(a.clone(prec + 16))
+	if l.Sign() == 0 {
+		return l.SetPrec(prec)
+	}
+	return bigfloat.Sqrt(l).SetPrec(prec)
+}
+
+// phase computes the principal value of the argument of a in (-pi, pi] with the given precision
+func phase(a *Float, prec uint) *big.Float {
+	wp := prec + 16
+	if a.A.Sign() == 0 {
+		if a.B.Sign() == 0 {
+			// the signed zeros select the side of the branch cut, atan2(+/-0, -0) = +/-pi
+			theta := big.NewFloat(0).SetPrec(prec)
+			if a.A.Signbit() {
+				theta.Set(bigfloat.PI(wp))
+			}
+			if a.B.Signbit() {
+				theta.Neg(theta)
+			}
+			return theta
+		}
+		theta := bigfloat.PI(wp)
+		theta.Quo(theta, big.NewFloat(2).SetPrec(wp))
+		if a.B.Sign() < 0 {
+			theta.Neg(theta)
+		}
+		return theta.SetPrec(prec)
+	}
+
+	theta := big.NewFloat(0).SetPrec(wp)
+	theta.Quo(a.B, a.A)
+	theta = arctan(theta)
+	if a.A.Sign() < 0 {
+		if a.B.Signbit() {
+			theta.Sub(theta, bigfloat.PI(wp))
+		} else {
+			theta.Add(theta, bigfloat.PI(wp))
+		}
+	}
+	return theta.SetPrec(prec)
+}
+
+// Modulus computes the absolute value |f|, which is +Inf for the point at infinity. big.Float
+// has no NaN, so the modulus of NaN is nil
+func (f *Float) Modulus() *big.Float {
+	if f.nan {
+		return nil
+	}
+	if f.IsInf() {
+		return big.NewFloat(0).SetPrec(f.A.Prec()).SetInf(false)
+	}
+	return modulus(f, f.A.Prec())
+}
+
+// Phase computes the argument of f in the range [-pi, pi]. The argument of NaN and of the point
+// at infinity is undefined and big.Float has no NaN, so their phase is nil
+// https://en.wikipedia.org/wiki/Argument_(complex_analysis)
+func (f *Float) Phase() *big.Float {
+	if f.nan || f.IsInf() {
+		return nil
+	}
+	return phase(f, f.A.Prec())
+}
+
+// Polar returns the absolute value r and the phase theta of f, such that f = r e^(i theta),
+// theta is nil for NaN and infinity and r is nil for NaN
+// https://en.wikipedia.org/wiki/Polar_coordinate_system#Complex_numbers
+func (f *Float) Polar() (r, theta *big.Float) {
+	return f.Modulus(), f.Phase()
+}
+
+// FromPolar creates a new imaginary number r e^(i theta) with the given precision
+func FromPolar(r, theta *big.Float, prec uint) *Float {
+	if r.IsInf() || theta.IsInf() {
+		z := newFloat(prec)
+		switch {
+		case theta.IsInf() && r.Sign() == 0:
+			return z
+		case theta.IsInf():
+			return z.SetNaN()
+		}
+		return z.SetInf()
+	}
+	wp := prec + 16
+	sin, cos := sinCos(theta, wp)
+	cos.Mul(cos, r)
+	sin.Mul(sin, r)
+	return NewFloat(cos.SetPrec(prec), sin.SetPrec(prec))
+}
+
+// sum computes f with function for the sum or difference of a and b, inf +/- inf is NaN
+func (f *Float) sum(a, b *Float, function func() *Float) *Float {
+	if a.nan || b.nan || (a.IsInf() && b.IsInf()) {
+		return f.SetNaN()
+	}
+	if a.IsInf() || b.IsInf() {
+		return f.SetInf()
+	}
+	return f.finite(function)
 }
 
 // Add add two imaginary numbers
 func (f *Float) Add(a, b *Float) *Float {
-	f.A.Add(a.A, b.A)
-	f.B.Add(a.B, b.B)
-	return f
+	return f.sum(a, b, func() *Float {
+		f.A.Add(a.A, b.A)
+		f.B.Add(a.B, b.B)
+		return f
+	})
 }
 
 // Sub subtracts two imaginary numbers
 func (f *Float) Sub(a, b *Float) *Float {
-	f.A.Sub(a.A, b.A)
-	f.B.Sub(a.B, b.B)
-	return f
+	return f.sum(a, b, func() *Float {
+		f.A.Sub(a.A, b.A)
+		f.B.Sub(a.B, b.B)
+		return f
+	})
 }
 
-// Mul multiples two imaginary numbers
+// Mul multiples two imaginary numbers, 0 * inf is NaN
 func (f *Float) Mul(a, b *Float) *Float {
-	x1, x2, x3, x4 :=
-		big.NewFloat(0).SetPrec(f.A.Prec()), big.NewFloat(0).SetPrec(f.B.Prec()),
-		big.NewFloat(0).SetPrec(f.B.Prec()), big.NewFloat(0).SetPrec(f.A.Prec())
-	x1.Mul(a.A, b.A) // a*a
-	x2.Mul(a.A, b.B) // a*ib
-	x3.Mul(a.B, b.A) // ib*a
-	x4.Mul(a.B, b.B) // i^2 * b = -b
-	f.A.Add(x1, x4.Neg(x4))
-	f.B.Add(x2, x3)
-	return f
+	if a.nan || b.nan || (a.IsInf() && b.isZero()) || (a.isZero() && b.IsInf()) {
+		return f.SetNaN()
+	}
+	if a.IsInf() || b.IsInf() {
+		return f.SetInf()
+	}
+	return f.finite(func() *Float {
+		// the products are exact so each part is rounded once
+		x1, x2, x3, x4 :=
+			big.NewFloat(0).SetPrec(a.A.Prec()+b.A.Prec()), big.NewFloat(0).SetPrec(a.A.Prec()+b.B.Prec()),
+			big.NewFloat(0).SetPrec(a.B.Prec()+b.A.Prec()), big.NewFloat(0).SetPrec(a.B.Prec()+b.B.Prec())
+		x1.Mul(a.A, b.A) // a*a
+		x2.Mul(a.A, b.B) // a*ib
+		x3.Mul(a.B, b.A) // ib*a
+		x4.Mul(a.B, b.B) // i^2 * b = -b
+		f.A.Sub(x1, x4)
+		f.B.Add(x2, x3)
+		return f
+	})
 }
 
 // Conj computes the complex conjugate of a
 func (f *Float) Conj(a *Float) *Float {
-	f.A.Set(a.A)
-	f.B.Neg(a.B)
-	return f
+	return f.unary(a, infinite, func() *Float {
+		f.A.Set(a.A)
+		f.B.Neg(a.B)
+		return f
+	})
 }
 
-// Div divides two imaginary numbers
+// Div divides two imaginary numbers, x/0 is infinite and 0/0 and inf/inf are NaN
 func (f *Float) Div(a, b *Float) *Float {
-	c := NewFloat(big.NewFloat(0).SetPrec(f.A.Prec()), big.NewFloat(0).SetPrec(f.B.Prec()))
-	c.Conj(b)
-	x := NewFloat(a.A.Copy(a.A), a.B.Copy(a.B))
-	y := NewFloat(b.A.Copy(b.A), b.B.Copy(b.B))
-	x.Mul(x, c)
-	y.Mul(y, c)
-	f.A.Quo(x.A, y.A)
-	f.B.Quo(x.B, y.A)
+	switch {
+	case a.nan || b.nan, a.isZero() && b.isZero(), a.IsInf() && b.IsInf():
+		return f.SetNaN()
+	case a.IsInf() || b.isZero():
+		return f.SetInf()
+	}
+	return f.finite(func() *Float {
+		if b.IsInf() {
+			f.A.SetInt64(0)
+			f.B.SetInt64(0)
+			return f
+		}
+		return f.set(quo(a, b, f.A.Prec()+floatGuard), false, false)
+	})
+}
+
+// quo computes a/b = a conj(b)/|b|^2 with the given precision, the numerator is computed
+// from the exact products so that a part that cancels is still accurate
+func quo(a, b *Float, prec uint) *Float {
+	ep := a.A.Prec() + a.B.Prec() + b.A.Prec() + b.B.Prec() + prec
+	product := func(x, y *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(ep).Mul(x, y)
+	}
+	y := newFloat(prec)
+	y.A.Add(product(a.A, b.A), product(a.B, b.B))
+	y.B.Sub(product(a.B, b.A), product(a.A, b.B))
+	n := norm(b.clone(prec))
+	y.A.Quo(y.A, n)
+	y.B.Quo(y.B, n)
+	return y
+}
+
+// quoLoss computes the number of bits of the parts of a conj(b) that cancel, which quo divides
+// exactly, but which the rounding errors of a and b still dominate
+func quoLoss(a, b *Float) uint {
+	ep := a.A.Prec() + a.B.Prec() + b.A.Prec() + b.B.Prec()
+	product := func(x, y *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(ep).Mul(x, y)
+	}
+	lost := 0
+	loss := func(x, y *big.Float) {
+		if x.Sign() == 0 || y.Sign() == 0 {
+			return
+		}
+		e := x.MantExp(nil)
+		if f := y.MantExp(nil); f > e {
+			e = f
+		}
+		sum := big.NewFloat(0).SetPrec(ep).Add(x, y)
+		if sum.Sign() == 0 {
+			// every bit cancels
+			e, sum = int(ep), big.NewFloat(1)
+		}
+		if l := e - sum.MantExp(nil); l > lost {
+			lost = l
+		}
+	}
+	loss(product(a.A, b.A), product(a.B, b.B))
+	loss(product(a.B, b.A), product(a.A, b.B).Neg(product(a.A, b.B)))
+	return uint(lost)
+}
+
+// round rounds x into z if every value within d of x rounds to the same value
+// with the precision and mode of z
+func round(z, x, d *big.Float) bool {
+	if x.IsInf() || d.Sign() == 0 {
+		z.Set(x)
+		return true
+	}
+	if d.IsInf() {
+		return false
+	}
+
+	// with d rounded up to a power of two x - d and x + d are exact
+	e := d.MantExp(nil)
+	if d.Cmp(big.NewFloat(0).SetMantExp(big.NewFloat(.5), e)) > 0 {
+		e++
+	}
+	d = big.NewFloat(0).SetMantExp(big.NewFloat(.5), e)
+	prec := x.Prec() + 2
+	if x.Sign() != 0 && x.MantExp(nil) > e {
+		prec += uint(x.MantExp(nil) - e)
+	}
+	lo := big.NewFloat(0).SetPrec(prec).Sub(x, d)
+	hi := big.NewFloat(0).SetPrec(prec).Add(x, d)
+	l := big.NewFloat(0).SetPrec(z.Prec()).SetMode(z.Mode()).Set(lo)
+	h := big.NewFloat(0).SetPrec(z.Prec()).SetMode(z.Mode()).Set(hi)
+	if l.Cmp(h) != 0 || l.Signbit() != h.Signbit() {
+		return false
+	}
+	z.Set(x)
+	return true
+}
+
+// zivRetries is the number of times ziv doubles the working precision before it gives up
+const zivRetries = 5
+
+// zivRound rounds the part x of an approximation with the error bound d into z if every value
+// within d of x rounds to the same value. A zero part is only exact if the previous
+// approximation has the same zero, which the functions compute for the parts that vanish
+func zivRound(z, x, previous, d *big.Float) bool {
+	if x.Sign() == 0 {
+		if previous == nil || previous.Sign() != 0 || previous.Signbit() != x.Signbit() {
+			return false
+		}
+		z.Set(x)
+		return true
+	}
+	return round(z, x, d)
+}
+
+// ziv computes function with increasing working precision until the real and imaginary
+// parts round to the same values with the precision and mode of f. An approximation with the
+// working precision wp is accurate to wp - floatGuard bits of its largest part, so the error
+// of each part is bounded by 2^(floatGuard - wp) max(|Re y|, |Im y|). If the rounding can't be
+// certified after zivRetries doublings, such as for an exact result with more bits than f that
+// isn't detected, f is the last approximation and Uncertain is true
+// https://doi.org/10.1145/114697.116813
+func (f *Float) ziv(function func(prec uint) *Float) *Float {
+	prec := f.A.Prec()
+	if p := f.B.Prec(); p > prec {
+		prec = p
+	}
+	var x *Float
+	for i, wp := 0, prec+2*floatGuard; i <= zivRetries; i, wp = i+1, 2*wp {
+		y := function(wp)
+		if y.A.IsInf() || y.B.IsInf() {
+			return f.set(y, false, false)
+		}
+		d := big.NewFloat(0).SetPrec(64).Abs(y.A)
+		if b := big.NewFloat(0).SetPrec(64).Abs(y.B); b.Cmp(d) > 0 {
+			d = b
+		}
+		d.SetMantExp(d, int(floatGuard)-int(wp))
+		var a, b *big.Float
+		if x != nil {
+			a, b = x.A, x.B
+		}
+		re, im := big.NewFloat(0).SetPrec(f.A.Prec()).SetMode(f.A.Mode()),
+			big.NewFloat(0).SetPrec(f.B.Prec()).SetMode(f.B.Mode())
+		if zivRound(re, y.A, a, d) && zivRound(im, y.B, b, d) {
+			f.A.Set(re)
+			f.B.Set(im)
+			return f
+		}
+		x = y
+	}
+	f.set(x, false, false)
+	f.uncertain = true
 	return f
 }
 
-// Sqrt computes the square root of the complex number
-// https://www.johndcook.com/blog/2020/06/09/complex-square-root/
-func (f *Float) Sqrt(a *Float) *Float {
-	x := big.NewFloat(0).SetPrec(f.A.Prec())
-	y := big.NewFloat(0).SetPrec(f.B.Prec())
-	l := big.NewFloat(0).SetPrec(f.A.Prec())
-	x.Mul(a.A, a.A)
-	y.Mul(a.B, a.B)
-	l.Add(x, y)
+// Uncertain determines if the last correctly rounded function that set f, such as Sqrt, Exp,
+// Log, Sin, Cos, Tan and Pow, couldn't certify the rounding of the parts, which are then within
+// an ulp of the correctly rounded parts
+func (f *Float) Uncertain() bool {
+	return f.uncertain
+}
+
+// sqrt computes the square root of a with the given precision
+func sqrt(a *Float, prec uint) *Float {
+	l := norm(a.clone(prec))
+	if l.Sign() == 0 {
+		return newFloat(prec)
+	}
 	l = bigfloat.Sqrt(l)
 
-	aa := big.NewFloat(0).SetPrec(f.A.Prec())
-	aa.Add(l, a.A)
-	aa.Quo(aa, big.NewFloat(2).SetPrec(f.A.Prec()))
-	aa = bigfloat.Sqrt(aa)
+	x := big.NewFloat(0).SetPrec(prec)
+	x.Abs(a.A)
+	x.Add(x, l)
+	x.Quo(x, big.NewFloat(2).SetPrec(prec))
+	x = bigfloat.Sqrt(x)
+	y := big.NewFloat(0).SetPrec(prec)
+	y.Quo(a.B, x)
+	y.Quo(y, big.NewFloat(2).SetPrec(prec))
+
+	if a.A.Sign() >= 0 {
+		return NewFloat(x, y)
+	}
+	if a.B.Signbit() {
+		x.Neg(x)
+	}
+	return NewFloat(y.Abs(y), x)
+}
 
-	f.B.Sub(l, a.A)
-	f.B.Quo(f.B, big.NewFloat(2).SetPrec(f.B.Prec()))
-	f.B = bigfloat.Sqrt(f.B)
-	f.B.Mul(big.NewFloat(float64(a.B.Sign())).SetPrec(f.B.Prec()), f.B)
-	f.A = aa
+// sqrtExact computes the square root of a if it is exact with the precision of a, or nil
+func sqrtExact(a *Float) *Float {
+	prec := a.A.Prec()
+	if p := a.B.Prec(); p > prec {
+		prec = p
+	}
+	y := sqrt(a, 2*prec+floatGuard).clone(prec)
+	// y is exact if y^2 = a
+	x, z := new(big.Rat), new(big.Rat)
+	y.A.Rat(x)
+	y.B.Rat(z)
+	re := new(big.Rat).Mul(x, x)
+	re.Sub(re, new(big.Rat).Mul(z, z))
+	im := new(big.Rat).Mul(x, z)
+	im.Add(im, im)
+	if r, _ := a.A.Rat(nil); r.Cmp(re) != 0 {
+		return nil
+	}
+	if r, _ := a.B.Rat(nil); r.Cmp(im) != 0 {
+		return nil
+	}
+	return y
+}
 
-	return f
+// Sqrt computes the square root of the complex number, exactly rounded if it is exact
+// https://www.johndcook.com/blog/2020/06/09/complex-square-root/
+func (f *Float) Sqrt(a *Float) *Float {
+	return f.unary(a, infinite, func() *Float {
+		if y := sqrtExact(a); y != nil {
+			return f.set(y, false, false)
+		}
+		return f.ziv(func(prec uint) *Float {
+			return sqrt(a, prec)
+		})
+	})
 }
 
-// Atan2 computes atan2 of x
+// Atan2 computes atan2 of x, atan2(+/-0, +0) = +/-0 and atan2(+/-0, -0) = +/-pi
 // https://en.wikipedia.org/wiki/Atan2
 func (f *Float) Atan2(x *Float) *Float {
-	a := x.A
-	b := x.B
-
-	if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) > 0 {
-		f.Arg(x)
-	} else if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) < 0 &&
-		b.Cmp(big.NewFloat(0).SetPrec(b.Prec())) >= 0 {
-		f.Arg(x)
-		f.A.Add(f.A, bigfloat.PI(a.Prec()))
-	} else if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) < 0 &&
-		b.Cmp(big.NewFloat(0).SetPrec(b.Prec())) < 0 {
-		f.Arg(x)
-		f.A.Sub(f.A, bigfloat.PI(f.A.Prec()))
-	} else {
-		f.Arg(x)
-	}
-
-	return f
+	return f.unary(x, undefined, func() *Float {
+		f.A.Set(phase(x, f.A.Prec()+floatGuard))
+		f.B.SetInt64(0)
+		return f
+	})
 }
 
-// Arg computes arg(x + yi) = tan-1(y/x)
+// Arg computes arg(x + yi) = tan-1(y/x), with tan-1(+/-0/0) = +/-0
 // https://mathworld.wolfram.com/ComplexArgument.html
 func (f *Float) Arg(x *Float) *Float {
-	a := x.A
-	b := x.B
-	f.B = big.NewFloat(0).SetPrec(b.Prec())
-
-	if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) == 0 {
-		if b.Cmp(big.NewFloat(0).SetPrec(a.Prec())) < 0 {
-			f.A.Set(bigfloat.PI(a.Prec()))
-			f.A.Quo(f.A, big.NewFloat(2).SetPrec(a.Prec()))
-			f.A.Neg(f.A)
-		} else if b.Cmp(big.NewFloat(0).SetPrec(a.Prec())) == 0 {
-			f.A.SetInf(false)
+	return f.unary(x, undefined, func() *Float {
+		prec := f.A.Prec() + floatGuard
+		if x.isZero() {
+			f.A.Set(x.B)
+		} else if x.A.Sign() == 0 {
+			f.A.Set(phase(x, prec))
 		} else {
-			f.A.Set(bigfloat.PI(a.Prec()))
-			f.A.Quo(f.A, big.NewFloat(2).SetPrec(a.Prec()))
+			t := big.NewFloat(0).SetPrec(prec)
+			f.A.Set(arctan(t.Quo(x.B, x.A)))
 		}
-
+		f.B.SetInt64(0)
 		return f
-	}
+	})
+}
 
-	if a.Cmp(big.NewFloat(1).SetPrec(a.Prec())) == 0 &&
-		b.Cmp(big.NewFloat(0).SetPrec(b.Prec())) == 0 {
-		f.A.Set(big.NewFloat(0).SetPrec(a.Prec()))
-	} else if a.Cmp(big.NewFloat(1).SetPrec(a.Prec())) == 0 &&
-		b.Cmp(big.NewFloat(1).SetPrec(b.Prec())) == 0 {
-		f.A.Set(bigfloat.PI(a.Prec()))
-		f.A.Quo(f.A, big.NewFloat(4).SetPrec(a.Prec()))
-	} else if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) == 0 &&
-		b.Cmp(big.NewFloat(1).SetPrec(b.Prec())) == 0 {
-		f.A.Set(bigfloat.PI(b.Prec()))
-		f.A.Quo(f.A, big.NewFloat(2).SetPrec(b.Prec()))
-	} else if a.Cmp(big.NewFloat(-1).SetPrec(a.Prec())) == 0 &&
-		b.Cmp(big.NewFloat(0).SetPrec(b.Prec())) == 0 {
-		f.A.Set(bigfloat.PI(a.Prec()))
-	} else if a.Cmp(big.NewFloat(0).SetPrec(a.Prec())) == 0 &&
-		b.Cmp(big.NewFloat(-1).SetPrec(b.Prec())) == 0 {
-		f.A.Set(bigfloat.PI(b.Prec()))
-		f.A.Quo(f.A, big.NewFloat(2).SetPrec(b.Prec()))
-		f.A.Neg(f.A)
-	} else {
-		f.A.Quo(b, a)
-		f.A = bigfloat.Arctan(f.A)
+// exp computes e^x with the given precision
+func exp(x *Float, prec uint) *Float {
+	y := x.clone(prec)
+	e := bigfloat.Exp(y.A)
+	if y.B.Sign() == 0 {
+		// e^a can overflow, and inf * 0 is NaN
+		y.A.Set(e)
+		return y
 	}
-
-	return f
+	sin, cos := sinCos(y.B, prec)
+	y.A.Mul(e, cos)
+	y.B.Mul(e, sin)
+	return y
 }
 
 // Exp computes e^x for a complex number
 // https://www.wolframalpha.com/input/?i=e%5E%28x+%2B+yi%29
 func (f *Float) Exp(x *Float) *Float {
-	exp := bigfloat.Exp(x.A)
-	cos := bigfloat.Cos(x.B)
-	sin := bigfloat.Sin(x.B)
-	f.A.Mul(exp, cos)
-	f.B.Mul(exp, sin)
-	return f
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			// e^0 = 1 is exact
+			f.A.SetInt64(1)
+			f.B.Set(x.B)
+			return f
+		}
+		return f.ziv(func(prec uint) *Float {
+			return exp(x, prec)
+		})
+	})
+}
+
+// logGuard is the number of guard bits used by the logarithm and exponential variants
+const logGuard = 32
+
+// expm1 computes e^x - 1 for a real x without cancellation near zero
+func expm1(x *big.Float, prec uint) *big.Float {
+	wp := prec + logGuard
+	if x.Sign() == 0 || x.IsInf() {
+		y := bigfloat.Exp(big.NewFloat(0).SetPrec(wp).Set(x))
+		return y.Sub(y, big.NewFloat(1).SetPrec(wp))
+	}
+	if e := x.MantExp(nil); e < 0 {
+		if -e > int(wp) {
+			// e^x - 1 = x + x^2/2 + O(x^3)
+			y := big.NewFloat(0).SetPrec(wp).Mul(x, x)
+			y.Quo(y, big.NewFloat(2).SetPrec(wp))
+			return y.Add(y, x)
+		}
+		wp += uint(-e)
+	}
+	y := bigfloat.Exp(big.NewFloat(0).SetPrec(wp).Set(x))
+	return y.Sub(y, big.NewFloat(1).SetPrec(wp))
+}
+
+// expBase computes base^x = e^(x log(base)), exactly when x is a small real integer
+func (f *Float) expBase(x *Float, base int64) *Float {
+	prec := f.A.Prec()
+	if x.B.Sign() == 0 && !x.A.IsInf() && x.A.IsInt() {
+		if n, acc := x.A.Int64(); acc == big.Exact && -1<<16 <= n && n <= 1<<16 {
+			if base == 2 {
+				f.A.SetMantExp(big.NewFloat(1).SetPrec(prec), int(n))
+			} else {
+				m := n
+				if m < 0 {
+					m = -m
+				}
+				p := big.NewFloat(0).SetInt(big.NewInt(0).Exp(big.NewInt(base), big.NewInt(m), nil))
+				if n < 0 {
+					f.A.Quo(big.NewFloat(1).SetPrec(prec), p)
+				} else {
+					f.A.Set(p)
+				}
+			}
+			f.B.SetInt64(0)
+			return f
+		}
+	}
+
+	// the error of x log(base) is scaled by the exponent of x
+	wp := prec + logGuard
+	if e := x.A.MantExp(nil); e > 0 {
+		wp += uint(e)
+	}
+	if e := x.B.MantExp(nil); e > 0 {
+		wp += uint(e)
+	}
+	l := bigfloat.Log(big.NewFloat(float64(base)).SetPrec(wp))
+	y := x.clone(wp)
+	y.A.Mul(y.A, l)
+	y.B.Mul(y.B, l)
+	return f.set(exp(y, wp), false, x.B.Sign() == 0)
+}
+
+// Exp2 computes 2^x for a complex number
+func (f *Float) Exp2(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		return f.expBase(x, 2)
+	})
+}
+
+// Exp10 computes 10^x for a complex number
+func (f *Float) Exp10(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		return f.expBase(x, 10)
+	})
+}
+
+// Expm1 computes e^x - 1 for a complex number, accurately for x near zero
+// e^(a+bi) - 1 = (expm1(a)cos(b) - 2sin^2(b/2)) + e^a sin(b)i
+// https://en.wikipedia.org/wiki/Exponential_function#Computation
+func (f *Float) Expm1(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		prec := f.A.Prec()
+
+		// the two terms of the real part cancel to O(|x|^4) near zero
+		wp := prec + logGuard
+		if l := norm64(x); l > 0 && l < 1 {
+			wp += uint(-math.Log2(l))
+		}
+		y := x.clone(wp)
+		sin, cos := sinCos(y.B, wp)
+		em := expm1(y.A, wp)
+		if y.B.Sign() == 0 {
+			// e^a - 1 can overflow, and inf * 0 is NaN
+			y.A.Set(em)
+			return f.set(y, false, true)
+		}
+		em.Mul(em, cos)
+		s, _ := sinCos(big.NewFloat(0).SetPrec(wp).Quo(y.B, big.NewFloat(2).SetPrec(wp)), wp)
+		s.Mul(s, s)
+		s.Add(s, s)
+		y.A.Sub(em, s)
+		e := bigfloat.Exp(big.NewFloat(0).SetPrec(wp).Set(x.A))
+		y.B.Mul(e, sin)
+		return f.set(y, false, x.B.Sign() == 0)
+	})
+}
+
+// arctan computes the inverse tangent of x by halving the argument with
+// atan(x) = 2 atan(x/(1 + sqrt(1 + x^2))) before summing the Taylor series
+// https://en.wikipedia.org/wiki/Inverse_trigonometric_functions#Arctangent_addition_formula
+func arctan(x *big.Float) *big.Float {
+	prec := x.Prec()
+	if x.Sign() == 0 {
+		return big.NewFloat(0).SetPrec(prec).Set(x)
+	}
+	if x.IsInf() {
+		y := bigfloat.PI(prec)
+		y.Quo(y, big.NewFloat(2).SetPrec(prec))
+		if x.Sign() < 0 {
+			y.Neg(y)
+		}
+		return y
+	}
+
+	halvings := 8
+	wp := prec + uint(2*halvings) + 16
+	one := big.NewFloat(1).SetPrec(wp)
+	y := big.NewFloat(0).SetPrec(wp).Set(x)
+	for i := 0; i < halvings; i++ {
+		t := big.NewFloat(0).SetPrec(wp)
+		t.Mul(y, y)
+		t.Add(t, one)
+		t = bigfloat.Sqrt(t)
+		t.Add(t, one)
+		y.Quo(y, t)
+	}
+
+	yy := big.NewFloat(0).SetPrec(wp)
+	yy.Mul(y, y)
+	yy.Neg(yy)
+	sum := big.NewFloat(0).SetPrec(wp).Set(y)
+	term := big.NewFloat(0).SetPrec(wp).Set(y)
+	t := big.NewFloat(0).SetPrec(wp)
+	for n := 1; ; n++ {
+		term.Mul(term, yy)
+		t.Quo(term, big.NewFloat(float64(2*n+1)).SetPrec(wp))
+		sum.Add(sum, t)
+		if t.Sign() == 0 || t.MantExp(nil) < sum.MantExp(nil)-int(wp) {
+			break
+		}
+	}
+	sum.SetMantExp(sum, halvings)
+	return sum.SetPrec(prec)
+}
+
+// sinCos computes the sine and cosine of a real x with the given precision by reducing x
+// modulo pi/2 and halving the remainder t before summing the Taylor series, which are
+// doubled with sin(2t) = 2sin(t)(1 + c) and c(2t) = 2c(c + 2) where c = cos(t) - 1
+// https://en.wikipedia.org/wiki/List_of_trigonometric_identities#Double-angle_formulae
+func sinCos(x *big.Float, prec uint) (sin, cos *big.Float) {
+	if x.IsInf() {
+		panic(big.ErrNaN{})
+	}
+	halvings := 8
+	wp := prec + uint(2*halvings) + 16
+
+	// x = n pi/2 + t with the extra precision lost to the reduction
+	rp := wp + 64
+	if e := x.MantExp(nil); x.Sign() != 0 && e > 0 {
+		rp += uint(e)
+	}
+	halfpi := bigfloat.PI(rp)
+	halfpi.Quo(halfpi, big.NewFloat(2).SetPrec(rp))
+	n := big.NewFloat(0).SetPrec(rp)
+	n.Quo(x, halfpi)
+	half := big.NewFloat(.5).SetPrec(rp)
+	if n.Sign() < 0 {
+		half.Neg(half)
+	}
+	i, _ := n.Add(n, half).Int(nil)
+	n.SetInt(i)
+	n.Mul(n, halfpi)
+	t := big.NewFloat(0).SetPrec(rp)
+	t.Sub(x, n)
+	t.SetPrec(wp)
+	t.SetMantExp(t, -halvings)
+
+	tt := big.NewFloat(0).SetPrec(wp)
+	tt.Mul(t, t)
+	tt.Neg(tt)
+	s, c := big.NewFloat(0).SetPrec(wp).Set(t), big.NewFloat(0).SetPrec(wp)
+	ts, tc := big.NewFloat(0).SetPrec(wp).Set(t), big.NewFloat(1).SetPrec(wp)
+	for k := 1; t.Sign() != 0; k++ {
+		ts.Mul(ts, tt)
+		ts.Quo(ts, big.NewFloat(float64(2*k*(2*k+1))).SetPrec(wp))
+		s.Add(s, ts)
+		tc.Mul(tc, tt)
+		tc.Quo(tc, big.NewFloat(float64((2*k-1)*2*k)).SetPrec(wp))
+		c.Add(c, tc)
+		if ts.Sign() == 0 || ts.MantExp(nil) < s.MantExp(nil)-int(wp) {
+			break
+		}
+	}
+	one, two := big.NewFloat(1).SetPrec(wp), big.NewFloat(2).SetPrec(wp)
+	u := big.NewFloat(0).SetPrec(wp)
+	for k := 0; k < halvings; k++ {
+		u.Add(c, one)
+		s.Mul(s, u)
+		s.Add(s, s)
+		u.Add(c, two)
+		c.Mul(c, u)
+		c.Add(c, c)
+	}
+	c.Add(c, one)
+
+	q := i.Mod(i, big.NewInt(4)).Int64()
+	switch q {
+	case 1:
+		s, c = c, s.Neg(s)
+	case 2:
+		s, c = s.Neg(s), c.Neg(c)
+	case 3:
+		s, c = c.Neg(c), s
+	}
+	return s.SetPrec(prec), c.SetPrec(prec)
+}
+
+// sinhCosh computes the hyperbolic sine and cosine of a real x with the given precision
+// sinh(x) = (expm1(x) - expm1(-x))/2 and cosh(x) = 1 + (expm1(x) + expm1(-x))/2
+func sinhCosh(x *big.Float, prec uint) (sinh, cosh *big.Float) {
+	p := expm1(x, prec)
+	m := expm1(big.NewFloat(0).SetPrec(prec).Neg(x), prec)
+	two := big.NewFloat(2).SetPrec(p.Prec())
+	sinh = big.NewFloat(0).SetPrec(p.Prec()).Sub(p, m)
+	sinh.Quo(sinh, two)
+	cosh = big.NewFloat(0).SetPrec(p.Prec()).Add(p, m)
+	cosh.Quo(cosh, two)
+	cosh.Add(cosh, big.NewFloat(1).SetPrec(p.Prec()))
+	return sinh, cosh
+}
+
+// cos computes cos(a + bi) = cos(a)cosh(b) - sin(a)sinh(b)i with the given precision
+func cos(x *Float, prec uint) *Float {
+	sin, cos := sinCos(x.A, prec)
+	sinh, cosh := sinhCosh(x.B, prec)
+	y := newFloat(prec)
+	y.A.Mul(cos, cosh)
+	if sin.Sign() != 0 {
+		// cosh and sinh can overflow, and inf * 0 is NaN
+		y.B.Mul(sin, sinh)
+		y.B.Neg(y.B)
+	}
+	return y
 }
 
 // Cos computes cosine of a number
 // https://www.wolframalpha.com/input/?i=cos%28x+%2B+yi%29
 func (f *Float) Cos(x *Float) *Float {
-	y1 := big.NewFloat(0).SetPrec(x.B.Prec())
-	y1.Set(x.B)
-	y1.Neg(y1)
-	x1 := big.NewFloat(0).SetPrec(x.A.Prec())
-	x1.Set(x.A)
-	a := NewFloat(y1, x1)
-
-	y2 := big.NewFloat(0).SetPrec(x.B.Prec())
-	y2.Set(x.B)
-	x2 := big.NewFloat(0).SetPrec(x.A.Prec())
-	x2.Set(x.A)
-	x2.Neg(x2)
-	b := NewFloat(y2, x2)
-
-	a.Exp(a)
-	b.Exp(b)
-	a.Add(a, b)
-	x3 := NewFloat(big.NewFloat(.5).SetPrec(x.A.Prec()), big.NewFloat(0).SetPrec(x.B.Prec()))
-	f.Mul(a, x3)
-	return f
+	return f.unary(x, undefined, func() *Float {
+		if x.isZero() {
+			// cos(0) = 1 is exact
+			f.A.SetInt64(1)
+			f.B.SetInt64(0)
+			return f
+		}
+		return f.ziv(func(prec uint) *Float {
+			return cos(x, prec)
+		})
+	})
+}
+
+// sin computes sin(a + bi) = sin(a)cosh(b) + cos(a)sinh(b)i with the given precision
+func sin(x *Float, prec uint) *Float {
+	sin, cos := sinCos(x.A, prec)
+	sinh, cosh := sinhCosh(x.B, prec)
+	y := newFloat(prec)
+	if sin.Sign() != 0 {
+		// cosh and sinh can overflow, and inf * 0 is NaN
+		y.A.Mul(sin, cosh)
+	}
+	y.B.Mul(cos, sinh)
+	return y
 }
 
 // Sin computes sine of a number
 // https://www.wolframalpha.com/input/?i=sin%28x+%2B+yi%29
 func (f *Float) Sin(x *Float) *Float {
-	y1 := big.NewFloat(0).SetPrec(x.B.Prec())
-	y1.Set(x.B)
-	x1 := big.NewFloat(0).SetPrec(x.A.Prec())
-	x1.Set(x.A)
-	x1.Neg(x1)
-	a := NewFloat(y1, x1)
-
-	y2 := big.NewFloat(0).SetPrec(x.B.Prec())
-	y2.Set(x.B)
-	y2.Neg(y2)
-	x2 := big.NewFloat(0).SetPrec(x.A.Prec())
-	x2.Set(x.A)
-	b := NewFloat(y2, x2)
-
-	a.Exp(a)
-	b.Exp(b)
-	a.Sub(a, b)
-	x3 := NewFloat(big.NewFloat(0).SetPrec(x.A.Prec()), big.NewFloat(.5).SetPrec(x.B.Prec()))
-	f.Mul(a, x3)
-	return f
+	return f.unary(x, undefined, func() *Float {
+		return f.ziv(func(prec uint) *Float {
+			return sin(x, prec)
+		})
+	})
+}
+
+// tan computes tan(a + bi) = (sin(2a) + sinh(2b)i)/(cos(2a) + cosh(2b)) with the given precision
+func tan(x *Float, prec uint) *Float {
+	// tan(a + bi) = (sin(a)cos(a) + sinh(b)cosh(b)i)/(cos(a)^2 + sinh(b)^2)
+	// avoids the cancellation of cos(2a) + cosh(2b) next to the poles
+	y := newFloat(prec)
+	sin, cos := sinCos(x.A, prec)
+	sinh, cosh := sinhCosh(x.B, prec)
+	d := big.NewFloat(0).SetPrec(prec).Mul(cos, cos)
+	d.Add(d, big.NewFloat(0).SetPrec(prec).Mul(sinh, sinh))
+	if d.IsInf() {
+		// tan(a + bi) = sign(b)i + O(e^-2|b|)
+		y.B.SetInt64(int64(sinh.Sign()))
+		return y
+	}
+	y.A.Mul(sin, cos)
+	y.A.Quo(y.A, d)
+	y.B.Mul(sinh, cosh)
+	y.B.Quo(y.B, d)
+	return y
 }
 
 // Tan computes tangent of a number
 // https://en.wikipedia.org/wiki/Trigonometric_functions
 func (f *Float) Tan(x *Float) *Float {
-	a := big.NewFloat(0).SetPrec(x.A.Prec())
-	a.Set(x.A)
-	b := big.NewFloat(0).SetPrec(x.B.Prec())
-	b.Set(x.B)
-	y := NewFloat(a, b)
-	x.Sin(x)
-	y.Cos(y)
-	f.Div(x, y)
-	return f
+	return f.unary(x, undefined, func() *Float {
+		return f.ziv(func(prec uint) *Float {
+			return tan(x, prec)
+		})
+	})
+}
+
+// log computes the principal value of log(x) = log|x| + atan2(x)i with the given precision
+func log(x *Float, prec uint) *Float {
+	y := newFloat(prec)
+	l := norm(x.clone(prec))
+	if l.Sign() == 0 {
+		y.A.SetInf(true)
+	} else if e := l.MantExp(nil); e == 0 || e == 1 {
+		// |x|^2 - 1 cancels near the unit circle, so it is computed from the exact squares
+		// log|x| = log1p(a^2 - 1 + b^2)/2
+		ep := 2 * (x.A.Prec() + x.B.Prec() + prec)
+		a := big.NewFloat(0).SetPrec(ep).Mul(x.A, x.A)
+		a.Sub(a, big.NewFloat(1))
+		a.Add(a, big.NewFloat(0).SetPrec(ep).Mul(x.B, x.B))
+		y.A.Set(log1p(a.SetPrec(prec), prec))
+		y.A.Quo(y.A, big.NewFloat(2).SetPrec(prec))
+	} else {
+		y.A.Set(bigfloat.Log(l))
+		y.A.Quo(y.A, big.NewFloat(2).SetPrec(prec))
+	}
+	y.B.Set(phase(x, prec))
+	return y
 }
 
 // Log computes the natural log of x
 // https://en.wikipedia.org/wiki/Complex_logarithm
 func (f *Float) Log(x *Float) *Float {
-	a := x.A
-	aa := big.NewFloat(0).SetPrec(a.Prec())
-	aa.Mul(a, a)
-	b := x.B
-	bb := big.NewFloat(0).SetPrec(b.Prec())
-	bb.Mul(b, b)
-	real := big.NewFloat(0).SetPrec(a.Prec())
-	real.Add(aa, bb)
-	real = bigfloat.Log(bigfloat.Sqrt(real))
-	y := NewFloat(big.NewFloat(0).SetPrec(a.Prec()), big.NewFloat(0).SetPrec(b.Prec()))
-	y.Atan2(x)
-	f.A = real
-	f.B = y.A
+	return f.unary(x, infinite, func() *Float {
+		return f.ziv(func(prec uint) *Float {
+			return log(x, prec)
+		})
+	})
+}
+
+// logBase computes log(x)/log(base) with the given precision
+func logBase(x, base *Float, prec uint) *Float {
+	y, l := log(x, prec), log(base, prec)
+	if l.B.Sign() == 0 {
+		y.A.Quo(y.A, l.A)
+		y.B.Quo(y.B, l.A)
+		return y
+	}
+	if lost := quoLoss(y, l); lost > 0 {
+		// log(x) is nearly a real or imaginary multiple of log(base)
+		y, l = log(x, prec+lost), log(base, prec+lost)
+	}
+	return quo(y, l, prec)
+}
+
+// positive determines if x is a positive real number
+func positive(x *Float) bool {
+	return x.B.Sign() == 0 && x.A.Sign() > 0
+}
+
+// Log2 computes the base 2 log of x
+func (f *Float) Log2(x *Float) *Float {
+	return f.unary(x, infinite, func() *Float {
+		prec := f.A.Prec() + logGuard
+		base := NewFloat(big.NewFloat(2).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+		return f.set(logBase(x, base, prec), false, positive(x))
+	})
+}
+
+// Log10 computes the base 10 log of x
+func (f *Float) Log10(x *Float) *Float {
+	return f.unary(x, infinite, func() *Float {
+		prec := f.A.Prec() + logGuard
+		base := NewFloat(big.NewFloat(10).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+		return f.set(logBase(x, base, prec), false, positive(x))
+	})
+}
+
+// LogBase computes the log of x to the complex base, log(x)/log(base)
+// https://en.wikipedia.org/wiki/Complex_logarithm#Generalizations
+func (f *Float) LogBase(x, base *Float) *Float {
+	if x.nan || base.nan {
+		return f.SetNaN()
+	}
+	prec := f.A.Prec() + logGuard
+	if x.isZero() || x.IsInf() || base.isZero() || base.IsInf() ||
+		(base.A.Cmp(big.NewFloat(1)) == 0 && base.B.Sign() == 0) {
+		// the log of zero and infinity is infinite and the log of one is zero
+		l := func(x *Float) *Float {
+			if x.isZero() || x.IsInf() {
+				return newFloat(prec).SetInf()
+			}
+			return log(x, prec)
+		}
+		return f.Div(l(x), l(base))
+	}
+	return f.finite(func() *Float {
+		return f.set(logBase(x, base, prec), false, positive(x) && positive(base))
+	})
+}
+
+// log1p computes log(1 + x) for a real x >= -1 without cancellation near zero
+func log1p(x *big.Float, prec uint) *big.Float {
+	wp := prec + logGuard
+	if x.Sign() != 0 && !x.IsInf() {
+		if e := x.MantExp(nil); e < 0 {
+			if -e > int(wp) {
+				// log(1 + x) = x - x^2/2 + O(x^3)
+				y := big.NewFloat(0).SetPrec(wp).Mul(x, x)
+				y.Quo(y, big.NewFloat(-2).SetPrec(wp))
+				return y.Add(y, x)
+			}
+			wp += uint(-e)
+		}
+	}
+	y := big.NewFloat(1).SetPrec(wp)
+	y.Add(y, x)
+	if y.Sign() == 0 {
+		return y.SetInf(true)
+	}
+	return bigfloat.Log(y)
+}
+
+// Log1p computes log(1 + x) for a complex number, accurately for x near zero
+// log(1 + a + bi) = log1p(2a + a^2 + b^2)/2 + atan2(b, 1 + a)i
+// https://en.wikipedia.org/wiki/Natural_logarithm#lnp1
+func (f *Float) Log1p(x *Float) *Float {
+	return f.unary(x, infinite, func() *Float {
+		prec := f.A.Prec()
+
+		// 2a and a^2 + b^2 cancel to O(|x|^2) near zero
+		wp := prec + logGuard
+		if l := norm64(x); l > 0 && l < 1 {
+			wp += uint(-math.Log2(l))
+		}
+		y := x.clone(wp)
+		ep := 2*(x.A.Prec()+x.B.Prec()) + wp
+		t := big.NewFloat(0).SetPrec(ep).Mul(x.A, x.A)
+		t.Add(t, big.NewFloat(0).SetPrec(ep).Mul(x.B, x.B))
+		t.Add(t, x.A)
+		t.Add(t, x.A)
+		t.SetPrec(wp)
+		y.A.Add(y.A, big.NewFloat(1).SetPrec(wp))
+		y.B.Set(phase(y, wp))
+		y.A = log1p(t, wp)
+		y.A.Quo(y.A, big.NewFloat(2).SetPrec(y.A.Prec()))
+		return f.set(y, false, x.B.Sign() == 0 && x.A.Cmp(big.NewFloat(-1)) >= 0)
+	})
+}
+
+// pow computes x**y = e^(y log(x)) with the given precision
+func pow(x, y *Float, prec uint) *Float {
+	// the error of y log(x) is scaled by its exponent
+	l := log(x, prec)
+	l.Mul(l, y)
+	e := l.A.MantExp(nil)
+	if b := l.B.MantExp(nil); b > e {
+		e = b
+	}
+	var z *Float
+	if e > 0 {
+		wp := prec + uint(e)
+		l = log(x, wp)
+		l.Mul(l, y)
+		z = exp(l, wp)
+	} else {
+		z = exp(l, prec)
+	}
+	if x.B.Sign() == 0 && x.A.Sign() < 0 && y.B.Sign() == 0 && !y.A.IsInt() {
+		// x^y = |x|^y e^(i pi y) is imaginary for a real x < 0 and an odd multiple y of 1/2
+		if t := big.NewFloat(0).SetMantExp(y.A, 1); t.IsInt() {
+			z.A.SetInt64(0)
+		}
+	}
+	return z
+}
+
+// powExactBits is the largest number of bits of the exact power x^n of an integer n
+const powExactBits = 1 << 20
+
+// gaussianParts computes the Gaussian integer g and the exponent e with x = g 2^e
+func gaussianParts(x *Float) (*GaussianInt, int) {
+	e := 0
+	first := true
+	for _, part := range []*big.Float{x.A, x.B} {
+		if part.Sign() == 0 {
+			continue
+		}
+		// the least significant bit of the mantissa of part
+		m := part.MantExp(nil) - int(part.MinPrec())
+		if first || m < e {
+			e, first = m, false
+		}
+	}
+	integer := func(part *big.Float) *big.Int {
+		i, _ := big.NewFloat(0).SetMantExp(part, -e).Int(nil)
+		return i
+	}
+	return NewGaussianInt(integer(x.A), integer(x.B)), e
+}
+
+// powExact computes x^n for an integer n exactly rounded into f, or returns nil if x^n has more
+// than powExactBits bits. x^n = g^n 2^(en) is computed exactly with Gaussian integers and
+// x^-n = conj(g^n)/N(g^n) 2^(-en) has exact parts that are each rounded once
+func (f *Float) powExact(x *Float, n int64) *Float {
+	if n == math.MinInt64 {
+		// -n overflows
+		return nil
+	}
+	g, e := gaussianParts(x)
+	m := n
+	if m < 0 {
+		m = -m
+	}
+	bits := int64(g.A.BitLen())
+	if b := int64(g.B.BitLen()); b > bits {
+		bits = b
+	}
+	// bits m overflows for a large n, and m <= powExactBits keeps n e in the range of int
+	if m > powExactBits/bits {
+		return nil
+	}
+	y := newGaussianInt(1, 0)
+	for b := g.copy(); m > 0; m >>= 1 {
+		if m&1 == 1 {
+			y.Mul(y, b)
+		}
+		b.Mul(b, b)
+	}
+	exact := func(i *big.Int) *big.Float {
+		return big.NewFloat(0).SetPrec(uint(i.BitLen()) + 1).SetInt(i)
+	}
+	if n < 0 {
+		norm := exact(y.Norm())
+		f.A.Quo(exact(y.A), norm)
+		f.B.Quo(exact(y.B.Neg(y.B)), norm)
+	} else {
+		f.A.Set(exact(y.A))
+		f.B.Set(exact(y.B))
+	}
+	f.A.SetMantExp(f.A, int(n)*e)
+	f.B.SetMantExp(f.B, int(n)*e)
 	return f
 }
 
-// Pow computes x**y
+// Pow computes x**y, with 0**0 = 1, 0**y = 0 and inf**y = inf for Re y > 0, and
+// 0**y = inf and inf**y = 0 for Re y < 0. An integer power, or a half integer power of a number
+// with an exact square root, is computed exactly and rounded once
 // https://mathworld.wolfram.com/ComplexExponentiation.html
 func (f *Float) Pow(x *Float, y *Float) *Float {
-	if x.A.Cmp(big.NewFloat(0).SetPrec(x.A.Prec())) == 0 &&
-		x.B.Cmp(big.NewFloat(0).SetPrec(x.B.Prec())) == 0 &&
-		y.A.Cmp(big.NewFloat(0).SetPrec(y.A.Prec())) == 0 &&
-		y.B.Cmp(big.NewFloat(0).SetPrec(y.B.Prec())) == 0 {
-		f.A.SetInf(false)
-		return f
+	switch {
+	case x.nan || y.nan || y.IsInf():
+		return f.SetNaN()
+	case x.isZero() && y.isZero():
+		return f.finite(func() *Float {
+			f.A.SetInt64(1)
+			f.B.SetInt64(0)
+			return f
+		})
+	case x.isZero() || x.IsInf():
+		sign := y.A.Sign()
+		if x.IsInf() {
+			sign = -sign
+		}
+		if sign == 0 {
+			return f.SetNaN()
+		} else if sign < 0 {
+			return f.SetInf()
+		}
+		return f.finite(func() *Float {
+			f.A.SetInt64(0)
+			f.B.SetInt64(0)
+			return f
+		})
 	}
-
-	a := big.NewFloat(0).SetPrec(x.A.Prec())
-	a.Set(x.A)
-	aa := big.NewFloat(0).SetPrec(x.A.Prec())
-	aa.Mul(a, a)
-	b := big.NewFloat(0).SetPrec(x.B.Prec())
-	b.Set(x.B)
-	bb := big.NewFloat(0).SetPrec(x.B.Prec())
-	bb.Mul(b, b)
-	sum := big.NewFloat(0).SetPrec(x.A.Prec())
-	sum.Add(aa, bb)
-	c := big.NewFloat(0).SetPrec(y.A.Prec())
-	c.Set(y.A)
-	cc := big.NewFloat(0).SetPrec(c.Prec())
-	cc.Quo(c, big.NewFloat(2).SetPrec(c.Prec()))
-	e := bigfloat.Pow(sum, cc)
-	d := big.NewFloat(0).SetPrec(y.B.Prec())
-	d.Set(y.B)
-	arg := NewFloat(big.NewFloat(0).SetPrec(a.Prec()),
-		big.NewFloat(0).SetPrec(b.Prec()))
-	arg.Arg(x)
-	exp := big.NewFloat(0).SetPrec(arg.A.Prec())
-	exp.Mul(d, arg.A)
-	exp.Neg(exp)
-	exp = bigfloat.Exp(exp)
-	e.Mul(e, exp)
-
-	i := big.NewFloat(0).SetPrec(c.Prec())
-	i.Mul(c, arg.A)
-	j := big.NewFloat(0).SetPrec(d.Prec())
-	j.Mul(d, bigfloat.Log(sum))
-	j.Quo(j, big.NewFloat(2).SetPrec(d.Prec()))
-	i.Add(i, j)
-	cos := bigfloat.Cos(i)
-	cos.Mul(e, cos)
-	f.A.Set(cos)
-	sin := bigfloat.Sin(i)
-	sin.Mul(e, sin)
-	f.B.Set(sin)
-	return f
+	return f.finite(func() *Float {
+		if y.B.Sign() == 0 {
+			// an integer power is exact, and so is x^(n/2) = sqrt(x)^n for an exact square root
+			if y.A.IsInt() {
+				if n, acc := y.A.Int64(); acc == big.Exact && f.powExact(x, n) != nil {
+					return f
+				}
+			} else if t := big.NewFloat(0).SetMantExp(y.A, 1); t.IsInt() {
+				if n, acc := t.Int64(); acc == big.Exact {
+					if s := sqrtExact(x); s != nil && f.powExact(s, n) != nil {
+						return f
+					}
+				}
+			}
+		}
+		return f.ziv(func(prec uint) *Float {
+			return pow(x, y, prec)
+		})
+	})
 }
 
 // SetRat sets the value to a rational
@@ -668,13 +1454,16 @@ func (f *Float) Rat(r *Rational) {
 
 // String returns a string of the imaginary number
 func (f *Float) String() string {
+	if f.nan {
+		return "NaN"
+	}
 	if f.B.Cmp(big.NewFloat(0)) == 0 {
 		return f.A.String()
 	}
 	return f.A.String() + " + " + f.B.String() + "i"
 }
 
-// Rational is an imaginary number
+// Rational is an imaginary number, the receiver of a method may alias the operands, which are never changed
 type Rational struct {
 	A, B *big.Rat
 }
@@ -687,6 +1476,11 @@ func NewRational(a, b *big.Rat) *Rational {
 	}
 }
 
+// copy copies r into a new imaginary number
+func (r *Rational) copy() *Rational {
+	return NewRational(big.NewRat(0, 1).Set(r.A), big.NewRat(0, 1).Set(r.B))
+}
+
 // Add add two imaginary numbers
 func (r *Rational) Add(a, b *Rational) *Rational {
 	r.A.Add(a.A, b.A)
@@ -726,8 +1520,8 @@ func (r *Rational) Conj(a *Rational) *Rational {
 func (r *Rational) Div(a, b *Rational) *Rational {
 	c := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
 	c.Conj(b)
-	x := NewRational(a.A.Set(a.A), a.B.Set(a.B))
-	y := NewRational(b.A.Set(b.A), b.B.Set(b.B))
+	x := NewRational(big.NewRat(0, 1).Set(a.A), big.NewRat(0, 1).Set(a.B))
+	y := NewRational(big.NewRat(0, 1).Set(b.A), big.NewRat(0, 1).Set(b.B))
 	x.Mul(x, c)
 	y.Mul(y, c)
 	r.A.Quo(x.A, y.A)
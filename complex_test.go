@@ -5,10 +5,33 @@
 package big
 
 import (
+	"math"
 	"math/big"
+	"math/rand"
 	"testing"
+
+	"github.com/ALTree/bigfloat"
 )
 
+// rationals creates Rational numbers from pairs of integer parts divided by the denominator
+func rationals(denominator int64, parts ...int64) []Rational {
+	var values []Rational
+	for i := 0; i < len(parts); i += 2 {
+		values = append(values, *NewRational(big.NewRat(parts[i], denominator), big.NewRat(parts[i+1], denominator)))
+	}
+	return values
+}
+
+// rationalMatrix creates a matrix of Rational numbers with the precision from rows of pairs
+// of integer parts divided by the denominator
+func rationalMatrix(prec uint, denominator int64, rows ...[]int64) Matrix {
+	m := NewMatrix(prec)
+	for _, r := range rows {
+		m.Values = append(m.Values, rationals(denominator, r...))
+	}
+	return m
+}
+
 func TestMatrix_Add(t *testing.T) {
 	a1 := NewRational(big.NewRat(1, 1), big.NewRat(0, 1))
 	a2 := NewRational(big.NewRat(2, 1), big.NewRat(0, 1))
@@ -152,6 +175,64 @@ func TestFloat_Abs(t *testing.T) {
 	}
 }
 
+func TestFloat_AbsPrecision(t *testing.T) {
+	a := NewFloat(big.NewFloat(3).SetPrec(256), big.NewFloat(4).SetPrec(256))
+	a.Abs(a)
+	t.Log(a.String())
+	if a.String() != "5" {
+		t.Fatal("invalid result")
+	}
+	if a.A.Prec() != 256 || a.B.Prec() != 256 {
+		t.Fatal("invalid precision")
+	}
+}
+
+func TestFloat_Polar(t *testing.T) {
+	a := NewFloat(big.NewFloat(-1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	r, theta := a.Polar()
+	t.Log(r.String(), theta.String())
+	if r.String() != "1.414213562" || theta.String() != "2.35619449" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-1).SetPrec(64), big.NewFloat(-1).SetPrec(64))
+	theta = a.Phase()
+	t.Log(theta.String())
+	if theta.String() != "-2.35619449" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-2).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	r, theta = a.Polar()
+	t.Log(r.String(), theta.String())
+	if r.String() != "2" || theta.String() != "3.141592654" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	r, theta = a.Polar()
+	t.Log(r.String(), theta.String())
+	if r.String() != "0" || theta.String() != "0" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFromPolar(t *testing.T) {
+	a := FromPolar(big.NewFloat(2).SetPrec(64), big.NewFloat(1).SetPrec(64), 64)
+	t.Log(a.String())
+	if a.String() != "1.080604612 + 1.68294197i" {
+		t.Fatal("invalid result")
+	}
+
+	b := NewFloat(big.NewFloat(3).SetPrec(128), big.NewFloat(-7).SetPrec(128))
+	r, theta := b.Polar()
+	a = FromPolar(r, theta, 128)
+	a.Sub(a, b)
+	if m := a.Modulus(); m.Sign() != 0 && m.MantExp(nil) > -120 {
+		t.Fatal("invalid round trip", a.String())
+	}
+}
+
 func TestFloat_Div(t *testing.T) {
 	a := NewFloat(big.NewFloat(4), big.NewFloat(5))
 	b := NewFloat(big.NewFloat(2), big.NewFloat(6))
@@ -169,6 +250,42 @@ func TestFloat_Sqrt(t *testing.T) {
 	if a.String() != "3 + 2i" {
 		t.Fatal("invalid result")
 	}
+
+	// (|a| - Re a)/2 cancels for a small imaginary part, which is computed as Im a/(2 Re sqrt(a))
+	tiny := big.NewFloat(0).SetMantExp(big.NewFloat(1), -80)
+	for _, re := range []float64{1, -1} {
+		a = NewFloat(big.NewFloat(re).SetPrec(64), big.NewFloat(0).SetPrec(64).Set(tiny))
+		b := newFloat(512).Sqrt(a)
+		a.Sqrt(a)
+		if a.A.Cmp(b.A.SetPrec(64)) != 0 || a.B.Cmp(b.B.SetPrec(64)) != 0 || a.A.Sign() == 0 || a.B.Sign() == 0 {
+			t.Fatal("invalid result", re, a.String(), b.String())
+		}
+	}
+}
+
+func TestArctan(t *testing.T) {
+	const prec = 128
+	pi := bigfloat.PI(prec)
+	// atan(1) = pi/4, where the Taylor series converges slowest
+	if y := arctan(big.NewFloat(1).SetPrec(prec)); y.SetMantExp(y, 2).Cmp(pi) != 0 {
+		t.Fatal("invalid result", y.Text('g', 40), pi.Text('g', 40))
+	}
+	// atan(x) + atan(1/x) = pi/2
+	half := big.NewFloat(0).SetPrec(prec).SetMantExp(pi, -1)
+	for _, x := range []float64{1e-30, .999, 1.001, 3, 1e30} {
+		a := big.NewFloat(x).SetPrec(prec)
+		b := big.NewFloat(0).SetPrec(prec).Quo(big.NewFloat(1), a)
+		d := big.NewFloat(0).SetPrec(prec).Add(arctan(a), arctan(b))
+		d.Sub(d, half)
+		if d.Sign() != 0 && d.MantExp(nil) > -prec+2 {
+			t.Fatal("invalid result", x, d)
+		}
+	}
+	// atan(x) = x - x^3/3 + O(x^5) rounds to a tiny x
+	x := big.NewFloat(0).SetPrec(prec).SetMantExp(big.NewFloat(3), -200)
+	if y := arctan(x); y.Cmp(x) != 0 {
+		t.Fatal("invalid result", y)
+	}
 }
 
 func TestFloat_Exp(t *testing.T) {
@@ -206,6 +323,14 @@ func TestFloat_Exp(t *testing.T) {
 	if a.String() != "3.992324048 + 6.217676312i" {
 		t.Fatal("invalid result")
 	}
+
+	// the imaginary part is reduced modulo 2 pi before the trigonometric series
+	a = NewFloat(big.NewFloat(0), big.NewFloat(1e6))
+	a.Exp(a)
+	t.Log(a.String())
+	if a.String() != "0.9367521275 + -0.3499935022i" {
+		t.Fatal("invalid result")
+	}
 }
 
 func TestFloat_Cos(t *testing.T) {
@@ -354,6 +479,13 @@ func TestFloat_Log(t *testing.T) {
 	if a.String() != "0.8047189562 + 0.463647609i" {
 		t.Fatal("invalid result")
 	}
+
+	a = NewFloat(big.NewFloat(-1), big.NewFloat(0))
+	a.Log(a)
+	t.Log(a.String())
+	if a.String() != "0 + 3.141592654i" {
+		t.Fatal("invalid result")
+	}
 }
 
 func TestFloat_Pow(t *testing.T) {
@@ -398,9 +530,32 @@ func TestFloat_Pow(t *testing.T) {
 	c = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(0).SetPrec(64))
 	c.Pow(a, b)
 	t.Log(c.String())
-	if c.String() != "+Inf" {
+	if c.String() != "1" {
 		t.Fatal("invalid result")
 	}
+
+	// the exact powers overflow for huge integer exponents, which are too large to compute
+	tests := []struct {
+		exponent int
+		negative bool
+		want     string
+	}{
+		{62, false, "+Inf"},
+		{62, true, "0"},
+		{63, true, "0"},
+	}
+	for _, test := range tests {
+		a = NewFloat(big.NewFloat(3).SetPrec(64), big.NewFloat(0).SetPrec(64))
+		b = NewFloat(big.NewFloat(0).SetPrec(64).SetMantExp(big.NewFloat(1), test.exponent), big.NewFloat(0).SetPrec(64))
+		if test.negative {
+			b.A.Neg(b.A)
+		}
+		c = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(0).SetPrec(64))
+		c.Pow(a, b)
+		if c.String() != test.want {
+			t.Fatal("invalid result", b.A, c.String())
+		}
+	}
 }
 
 func TestRational_Div(t *testing.T) {
@@ -412,3 +567,215 @@ func TestRational_Div(t *testing.T) {
 		t.Fatal("invalid result")
 	}
 }
+
+func TestFloat_Log2(t *testing.T) {
+	a := NewFloat(big.NewFloat(1), big.NewFloat(1))
+	a.Log2(a)
+	t.Log(a.String())
+	if a.String() != "0.5 + 1.133090035i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(8), big.NewFloat(0))
+	a.Log2(a)
+	t.Log(a.String())
+	if a.String() != "3" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Log10(t *testing.T) {
+	a := NewFloat(big.NewFloat(1000), big.NewFloat(0))
+	a.Log10(a)
+	t.Log(a.String())
+	if a.String() != "3" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-100), big.NewFloat(0))
+	a.Log10(a)
+	t.Log(a.String())
+	if a.String() != "2 + 1.364376354i" {
+		t.Fatal("invalid result")
+	}
+}
+
+// faithful determines if x is within an ulp of the more precise reference
+func faithful(x, reference *big.Float) bool {
+	d := big.NewFloat(0).SetPrec(x.Prec()+reference.Prec()).Sub(x, reference)
+	return d.Sign() == 0 || (reference.Sign() != 0 && d.MantExp(nil) <= reference.MantExp(nil)-int(x.Prec()))
+}
+
+func TestFloat_LogBase(t *testing.T) {
+	a := NewFloat(big.NewFloat(1), big.NewFloat(2))
+	a.LogBase(a, NewFloat(big.NewFloat(0), big.NewFloat(1)))
+	t.Log(a.String())
+	if a.String() != "0.7048327647 + -0.5122999987i" {
+		t.Fatal("invalid result")
+	}
+
+	// the imaginary part of the quotient cancels when x is nearly a real multiple of the base
+	a = NewFloat(big.NewFloat(-1/math.E).SetPrec(64), big.NewFloat(0).SetPrec(64).SetMantExp(big.NewFloat(1), -64))
+	base := NewFloat(big.NewFloat(-1/math.E).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	b := newFloat(1024).LogBase(a, base)
+	a.LogBase(a, base)
+	t.Log(a.String())
+	if a.String() != "1 + 1.355691962e-20i" || !faithful(a.A, b.A) || !faithful(a.B, b.B) {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Exp2(t *testing.T) {
+	a := NewFloat(big.NewFloat(10), big.NewFloat(0))
+	a.Exp2(a)
+	t.Log(a.String())
+	if a.String() != "1024" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(.5), big.NewFloat(0))
+	a.Exp2(a)
+	t.Log(a.String())
+	if a.String() != "1.414213562" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Exp10(t *testing.T) {
+	a := NewFloat(big.NewFloat(-3), big.NewFloat(0))
+	a.Exp10(a)
+	t.Log(a.String())
+	if a.String() != "0.001" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1), big.NewFloat(1))
+	a.Exp10(a)
+	t.Log(a.String())
+	if a.String() != "-6.682015102 + 7.43980337i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Expm1(t *testing.T) {
+	a := NewFloat(big.NewFloat(1), big.NewFloat(1))
+	a.Expm1(a)
+	t.Log(a.String())
+	if a.String() != "0.4686939399 + 2.287355287i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1e-20), big.NewFloat(1e-10))
+	a.Expm1(a)
+	t.Log(a.String())
+	if a.String() != "5e-21 + 1e-10i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Log1p(t *testing.T) {
+	a := NewFloat(big.NewFloat(1), big.NewFloat(1))
+	a.Log1p(a)
+	t.Log(a.String())
+	if a.String() != "0.8047189562 + 0.463647609i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1e-20), big.NewFloat(1e-10))
+	a.Log1p(a)
+	t.Log(a.String())
+	if a.String() != "1.5e-20 + 1e-10i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-3), big.NewFloat(0))
+	a.Log1p(a)
+	t.Log(a.String())
+	if a.String() != "0.6931471806 + 3.141592654i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_CorrectRounding(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	random := func() *Float {
+		return NewFloat(big.NewFloat(rnd.NormFloat64()*4), big.NewFloat(rnd.NormFloat64()*4))
+	}
+	functions := map[string]func(f, x, y *Float) *Float{
+		"Sqrt": func(f, x, y *Float) *Float { return f.Sqrt(x) },
+		"Exp":  func(f, x, y *Float) *Float { return f.Exp(x) },
+		"Log":  func(f, x, y *Float) *Float { return f.Log(x) },
+		"Sin":  func(f, x, y *Float) *Float { return f.Sin(x) },
+		"Cos":  func(f, x, y *Float) *Float { return f.Cos(x) },
+		"Tan":  func(f, x, y *Float) *Float { return f.Tan(x) },
+		"Pow":  func(f, x, y *Float) *Float { return f.Pow(x, y) },
+	}
+	modes := []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf}
+	for i := 0; i < 32; i++ {
+		x, y := random(), random()
+		for name, function := range functions {
+			expected := function(newFloat(512), x, y)
+			for _, mode := range modes {
+				c := NewContext(53, mode)
+				f := function(c.NewFloat(nil, nil), x, y)
+				e := c.Round(expected.clone(512))
+				if f.A.Cmp(e.A) != 0 || f.B.Cmp(e.B) != 0 {
+					t.Fatal("invalid rounding", name, mode, x.String(), f.String(), e.String())
+				}
+			}
+		}
+	}
+}
+
+func TestFloat_Exact(t *testing.T) {
+	real := func(x float64) *Float {
+		return NewFloat(big.NewFloat(x), big.NewFloat(0))
+	}
+	third := func(mode big.RoundingMode) string {
+		x := big.NewFloat(0).SetPrec(64).SetMode(mode)
+		return x.Quo(big.NewFloat(1), big.NewFloat(3)).Text('g', 30)
+	}
+	tests := []struct {
+		name     string
+		function func(f *Float) *Float
+		a, b     string
+	}{
+		{"pow(-2, 2)", func(f *Float) *Float { return f.Pow(real(-2), real(2)) }, "4", "0"},
+		{"pow(2 + 3i, 5)", func(f *Float) *Float { return f.Pow(NewFloat(big.NewFloat(2), big.NewFloat(3)), real(5)) }, "122", "-597"},
+		{"pow(0.5, -3)", func(f *Float) *Float { return f.Pow(real(.5), real(-3)) }, "8", "0"},
+		{"pow(1 + 1i, -2)", func(f *Float) *Float { return f.Pow(NewFloat(big.NewFloat(1), big.NewFloat(1)), real(-2)) }, "0", "-0.5"},
+		{"pow(-4, 1/2)", func(f *Float) *Float { return f.Pow(real(-4), real(.5)) }, "0", "2"},
+		{"pow(4, 1/2)", func(f *Float) *Float { return f.Pow(real(4), real(.5)) }, "2", "0"},
+		{"pow(-3 + 4i, 3/2)", func(f *Float) *Float { return f.Pow(NewFloat(big.NewFloat(-3), big.NewFloat(4)), real(1.5)) }, "-11", "-2"},
+		{"sqrt(4)", func(f *Float) *Float { return f.Sqrt(real(4)) }, "2", "0"},
+		{"sqrt(-5 - 12i)", func(f *Float) *Float { return f.Sqrt(NewFloat(big.NewFloat(-5), big.NewFloat(-12))) }, "2", "-3"},
+		{"exp(0)", func(f *Float) *Float { return f.Exp(real(0)) }, "1", "0"},
+		{"cos(0)", func(f *Float) *Float { return f.Cos(real(0)) }, "1", "0"},
+		{"log(1)", func(f *Float) *Float { return f.Log(real(1)) }, "0", "0"},
+		{"sin(0)", func(f *Float) *Float { return f.Sin(real(0)) }, "0", "0"},
+	}
+	modes := []big.RoundingMode{big.ToNearestEven, big.ToZero, big.AwayFromZero, big.ToNegativeInf, big.ToPositiveInf}
+	for _, mode := range modes {
+		c := NewContext(64, mode)
+		for _, test := range tests {
+			f := test.function(c.NewFloat(nil, nil))
+			if a, b := f.A.Text('g', 30), f.B.Text('g', 30); a != test.a || b != test.b || f.Uncertain() {
+				t.Fatal("inexact result", test.name, mode, a, b)
+			}
+		}
+		f := c.NewFloat(nil, nil).Pow(real(3), real(-1))
+		if a := f.A.Text('g', 30); a != third(mode) || f.B.Sign() != 0 || f.Uncertain() {
+			t.Fatal("invalid rounding", mode, a, third(mode))
+		}
+	}
+
+	// 16^(1/4) = 2 isn't detected, so rounding toward zero can't be certified
+	f := NewContext(64, big.ToZero).NewFloat(nil, nil).Pow(real(16), real(.25))
+	if d := big.NewFloat(0).Sub(f.A, big.NewFloat(2)); !f.Uncertain() || d.Sign() != 0 && d.MantExp(nil) > -62 {
+		t.Fatal("invalid uncertain result", f.A.Text('g', 30), f.Uncertain())
+	}
+	f.Exp(f)
+	if f.Uncertain() {
+		t.Fatal("uncertain result")
+	}
+}
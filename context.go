@@ -0,0 +1,77 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// Context is the precision and rounding mode of the results of Float and Matrix functions.
+// Float functions round their result to the precision and mode of the receiver, so a
+// receiver created by a Context gets results that don't depend on the precision of the inputs
+type Context struct {
+	Prec uint
+	Mode big.RoundingMode
+}
+
+// NewContext creates a new context
+func NewContext(prec uint, mode big.RoundingMode) *Context {
+	return &Context{
+		Prec: prec,
+		Mode: mode,
+	}
+}
+
+// float creates a new big.Float with the value x rounded to the context
+func (c *Context) float(x *big.Float) *big.Float {
+	y := big.NewFloat(0).SetPrec(c.Prec).SetMode(c.Mode)
+	if x != nil {
+		y.Set(x)
+	}
+	return y
+}
+
+// NewFloat creates a new imaginary number a + bi rounded to the context, a nil part is zero
+func (c *Context) NewFloat(a, b *big.Float) *Float {
+	return NewFloat(c.float(a), c.float(b))
+}
+
+// Round rounds f to the context
+func (c *Context) Round(f *Float) *Float {
+	f.A.SetMode(c.Mode).SetPrec(c.Prec)
+	f.B.SetMode(c.Mode).SetPrec(c.Prec)
+	return f
+}
+
+// NewMatrix creates a new matrix with the context
+func (c *Context) NewMatrix() Matrix {
+	return Matrix{
+		Prec: c.Prec,
+		Mode: c.Mode,
+	}
+}
+
+// NewFloatMatrix creates a new float matrix with the context
+func (c *Context) NewFloatMatrix() FloatMatrix {
+	return FloatMatrix{
+		Prec: c.Prec,
+		Mode: c.Mode,
+	}
+}
+
+// Context returns the precision and rounding mode of f
+func (f *Float) Context() *Context {
+	return NewContext(f.A.Prec(), f.A.Mode())
+}
+
+// Context returns the precision and rounding mode of m
+func (m *Matrix) Context() *Context {
+	return NewContext(m.Prec, m.Mode)
+}
+
+// Context returns the precision and rounding mode of m
+func (m *FloatMatrix) Context() *Context {
+	return NewContext(m.Prec, m.Mode)
+}
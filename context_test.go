@@ -0,0 +1,86 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestContext_NewFloat(t *testing.T) {
+	c := NewContext(32, big.ToZero)
+	a := c.NewFloat(big.NewFloat(1).SetPrec(128).Quo(big.NewFloat(1), big.NewFloat(3)), nil)
+	if a.A.Prec() != 32 || a.B.Prec() != 32 || a.A.Mode() != big.ToZero || a.B.Mode() != big.ToZero {
+		t.Fatal("invalid context")
+	}
+	if a.B.Sign() != 0 {
+		t.Fatal("invalid result")
+	}
+	b := c.Round(NewFloat(big.NewFloat(1).SetPrec(128), big.NewFloat(2).SetPrec(16)))
+	if *b.Context() != *c {
+		t.Fatal("invalid context")
+	}
+}
+
+func TestContext_Precision(t *testing.T) {
+	c := NewContext(64, big.ToNearestEven)
+	x := NewFloat(big.NewFloat(1.5).SetPrec(256), big.NewFloat(.5).SetPrec(16))
+	y := NewFloat(big.NewFloat(.25).SetPrec(8), big.NewFloat(2).SetPrec(512))
+	functions := map[string]func(f *Float) *Float{
+		"Abs":  func(f *Float) *Float { return f.Abs(x) },
+		"Mul":  func(f *Float) *Float { return f.Mul(x, y) },
+		"Div":  func(f *Float) *Float { return f.Div(x, y) },
+		"Sqrt": func(f *Float) *Float { return f.Sqrt(x) },
+		"Exp":  func(f *Float) *Float { return f.Exp(x) },
+		"Log":  func(f *Float) *Float { return f.Log(x) },
+		"Sin":  func(f *Float) *Float { return f.Sin(x) },
+		"Cos":  func(f *Float) *Float { return f.Cos(x) },
+		"Tan":  func(f *Float) *Float { return f.Tan(x) },
+		"Pow":  func(f *Float) *Float { return f.Pow(x, y) },
+	}
+	for name, function := range functions {
+		f := function(c.NewFloat(nil, nil))
+		if f.A.Prec() != 64 || f.B.Prec() != 64 {
+			t.Fatal("invalid precision", name, f.A.Prec(), f.B.Prec())
+		}
+	}
+}
+
+func TestContext_Mode(t *testing.T) {
+	x := NewFloat(big.NewFloat(1), big.NewFloat(1))
+	lower := NewContext(64, big.ToNegativeInf).NewFloat(nil, nil)
+	upper := NewContext(64, big.ToPositiveInf).NewFloat(nil, nil)
+	functions := map[string]func(f *Float) *Float{
+		"Sqrt": func(f *Float) *Float { return f.Sqrt(x) },
+		"Exp":  func(f *Float) *Float { return f.Exp(x) },
+		"Log":  func(f *Float) *Float { return f.Log(x) },
+		"Sin":  func(f *Float) *Float { return f.Sin(x) },
+		"Cos":  func(f *Float) *Float { return f.Cos(x) },
+		"Tan":  func(f *Float) *Float { return f.Tan(x) },
+		"Pow":  func(f *Float) *Float { return f.Pow(x, x) },
+	}
+	for name, function := range functions {
+		function(lower)
+		function(upper)
+		if lower.A.Cmp(upper.A) >= 0 || lower.B.Cmp(upper.B) >= 0 {
+			t.Fatal("invalid rounding", name, lower.String(), upper.String())
+		}
+	}
+}
+
+func TestMatrix_Context(t *testing.T) {
+	a := NewRational(big.NewRat(2, 1), big.NewRat(0, 1))
+	lower, upper := NewContext(64, big.ToZero).NewMatrix(), NewContext(64, big.AwayFromZero).NewMatrix()
+	lower.Values = append(lower.Values, []Rational{*a})
+	upper.Values = append(upper.Values, []Rational{*NewRational(big.NewRat(2, 1), big.NewRat(0, 1))})
+	lower.Sqrt(&lower)
+	upper.Sqrt(&upper)
+	if lower.Values[0][0].A.Cmp(upper.Values[0][0].A) >= 0 {
+		t.Fatal("invalid rounding")
+	}
+	if lower.Context().Mode != big.ToZero {
+		t.Fatal("invalid context")
+	}
+}
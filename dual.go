@@ -0,0 +1,352 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+// Dual is a dual number a + b epsilon with epsilon^2 = 0, the value a and the derivative b
+// are complex numbers. The functions of a dual number propagate the exact derivative,
+// f(a + b epsilon) = f(a) + f'(a) b epsilon, which is forward mode automatic differentiation.
+// The results are rounded to the precision of the receiver, which may alias the operands,
+// which are never changed
+// https://en.wikipedia.org/wiki/Dual_number
+// https://en.wikipedia.org/wiki/Automatic_differentiation#Automatic_differentiation_using_dual_numbers
+type Dual struct {
+	A, B *Float
+}
+
+// NewDual creates a new dual number
+func NewDual(a, b *Float) *Dual {
+	return &Dual{
+		A: a,
+		B: b,
+	}
+}
+
+// newDual creates a zero dual number with the given precision
+func newDual(prec uint) *Dual {
+	return NewDual(newFloat(prec), newFloat(prec))
+}
+
+// Variable creates the dual number x + epsilon of the variable of differentiation x
+func Variable(x *Float) *Dual {
+	prec := x.A.Prec()
+	d := newDual(prec)
+	d.A.assign(x)
+	d.B.A.SetInt64(1)
+	return d
+}
+
+// newReal creates the real number x with the given precision
+func newReal(x int64, prec uint) *Float {
+	f := newFloat(prec)
+	f.A.SetInt64(x)
+	return f
+}
+
+// assign rounds x into f
+func (f *Float) assign(x *Float) {
+	f.set(x, false, false)
+	f.nan, f.uncertain = x.nan, false
+}
+
+// prec returns the working precision of d
+func (d *Dual) prec() uint {
+	return d.A.A.Prec() + floatGuard
+}
+
+// set rounds a and b into d
+func (d *Dual) set(a, b *Float) *Dual {
+	d.A.assign(a)
+	d.B.assign(b)
+	return d
+}
+
+// Add adds two dual numbers
+func (d *Dual) Add(x, y *Dual) *Dual {
+	prec := d.prec()
+	return d.set(newFloat(prec).Add(x.A, y.A), newFloat(prec).Add(x.B, y.B))
+}
+
+// Sub subtracts two dual numbers
+func (d *Dual) Sub(x, y *Dual) *Dual {
+	prec := d.prec()
+	return d.set(newFloat(prec).Sub(x.A, y.A), newFloat(prec).Sub(x.B, y.B))
+}
+
+// Neg negates a dual number
+func (d *Dual) Neg(x *Dual) *Dual {
+	prec := d.prec()
+	zero := newFloat(prec)
+	return d.set(newFloat(prec).Sub(zero, x.A), newFloat(prec).Sub(zero, x.B))
+}
+
+// Mul multiplies two dual numbers, (a + b epsilon)(c + d epsilon) = ac + (ad + bc) epsilon
+func (d *Dual) Mul(x, y *Dual) *Dual {
+	prec := d.prec()
+	b := newFloat(prec).Mul(x.A, y.B)
+	b.Add(b, newFloat(prec).Mul(x.B, y.A))
+	return d.set(newFloat(prec).Mul(x.A, y.A), b)
+}
+
+// chain sets d to f(x) = f(a) + f'(a) b epsilon for the value f and the derivative f1 of f at a
+func (d *Dual) chain(x *Dual, f, f1 *Float) *Dual {
+	return d.set(f, f1.Mul(f1, x.B))
+}
+
+// Div divides two dual numbers, (a + b epsilon)/(c + d epsilon) = a/c + (bc - ad)/c^2 epsilon
+func (d *Dual) Div(x, y *Dual) *Dual {
+	prec := d.prec()
+	b := newFloat(prec).Mul(x.B, y.A)
+	b.Sub(b, newFloat(prec).Mul(x.A, y.B))
+	c := newFloat(prec).Mul(y.A, y.A)
+	return d.set(newFloat(prec).Div(x.A, y.A), b.Div(b, c))
+}
+
+// Exp computes e^x, with exp'(a) = e^a
+func (d *Dual) Exp(x *Dual) *Dual {
+	f := newFloat(d.prec()).Exp(x.A)
+	return d.chain(x, f, f.clone(d.prec()))
+}
+
+// Log computes the natural log of x, with log'(a) = 1/a
+func (d *Dual) Log(x *Dual) *Dual {
+	prec := d.prec()
+	return d.chain(x, newFloat(prec).Log(x.A), newFloat(prec).Div(newReal(1, prec), x.A))
+}
+
+// Sin computes the sine of x, with sin'(a) = cos(a)
+func (d *Dual) Sin(x *Dual) *Dual {
+	prec := d.prec()
+	return d.chain(x, newFloat(prec).Sin(x.A), newFloat(prec).Cos(x.A))
+}
+
+// Cos computes the cosine of x, with cos'(a) = -sin(a)
+func (d *Dual) Cos(x *Dual) *Dual {
+	prec := d.prec()
+	f1 := newFloat(prec).Sin(x.A)
+	return d.chain(x, newFloat(prec).Cos(x.A), f1.Sub(newFloat(prec), f1))
+}
+
+// Tan computes the tangent of x, with tan'(a) = 1 + tan(a)^2
+func (d *Dual) Tan(x *Dual) *Dual {
+	prec := d.prec()
+	f := newFloat(prec).Tan(x.A)
+	f1 := newFloat(prec).Mul(f, f)
+	f1.Add(f1, newReal(1, prec))
+	return d.chain(x, f, f1)
+}
+
+// Sqrt computes the square root of x, with sqrt'(a) = 1/(2 sqrt(a))
+func (d *Dual) Sqrt(x *Dual) *Dual {
+	prec := d.prec()
+	f := newFloat(prec).Sqrt(x.A)
+	f1 := newFloat(prec).Add(f, f)
+	return d.chain(x, f, f1.Div(newReal(1, prec), f1))
+}
+
+// Pow computes x**y = e^(y log(x))
+func (d *Dual) Pow(x, y *Dual) *Dual {
+	l := newDual(d.prec()).Log(x)
+	l.Mul(l, y)
+	return d.Exp(l)
+}
+
+// String returns a string of the dual number
+func (d *Dual) String() string {
+	return "(" + d.A.String() + ") + (" + d.B.String() + ")e"
+}
+
+// HyperDual is a hyper-dual number a + b epsilon1 + c epsilon2 + d epsilon1 epsilon2 with
+// epsilon1^2 = epsilon2^2 = 0 and complex parts. The functions of a hyper-dual number propagate
+// the exact first and second derivatives,
+// f(x) = f(a) + f'(a) b epsilon1 + f'(a) c epsilon2 + (f'(a) d + f2(a) b c) epsilon1 epsilon2
+// for the second derivative f2 of f.
+// The results are rounded to the precision of the receiver, which may alias the operands,
+// which are never changed
+// https://doi.org/10.2514/6.2011-886
+type HyperDual struct {
+	A, B, C, D *Float
+}
+
+// NewHyperDual creates a new hyper-dual number
+func NewHyperDual(a, b, c, d *Float) *HyperDual {
+	return &HyperDual{
+		A: a,
+		B: b,
+		C: c,
+		D: d,
+	}
+}
+
+// newHyperDual creates a zero hyper-dual number with the given precision
+func newHyperDual(prec uint) *HyperDual {
+	return NewHyperDual(newFloat(prec), newFloat(prec), newFloat(prec), newFloat(prec))
+}
+
+// HyperVariable creates the hyper-dual number x + epsilon1 + epsilon2 of the variable of
+// differentiation x, the part of epsilon1 epsilon2 of f(x) is the second derivative of f at x
+func HyperVariable(x *Float) *HyperDual {
+	prec := x.A.Prec()
+	h := newHyperDual(prec)
+	h.A.assign(x)
+	h.B.A.SetInt64(1)
+	h.C.A.SetInt64(1)
+	return h
+}
+
+// parts returns the parts of h
+func (h *HyperDual) parts() [4]*Float {
+	return [4]*Float{h.A, h.B, h.C, h.D}
+}
+
+// prec returns the working precision of h
+func (h *HyperDual) prec() uint {
+	return h.A.A.Prec() + floatGuard
+}
+
+// set rounds the parts into h
+func (h *HyperDual) set(a, b, c, d *Float) *HyperDual {
+	h.A.assign(a)
+	h.B.assign(b)
+	h.C.assign(c)
+	h.D.assign(d)
+	return h
+}
+
+// elementwise sets h to the function of the parts of x and y
+func (h *HyperDual) elementwise(x, y *HyperDual, function func(f, a, b *Float) *Float) *HyperDual {
+	prec := h.prec()
+	var z [4]*Float
+	p, q := x.parts(), y.parts()
+	for i := range z {
+		z[i] = function(newFloat(prec), p[i], q[i])
+	}
+	return h.set(z[0], z[1], z[2], z[3])
+}
+
+// Add adds two hyper-dual numbers
+func (h *HyperDual) Add(x, y *HyperDual) *HyperDual {
+	return h.elementwise(x, y, (*Float).Add)
+}
+
+// Sub subtracts two hyper-dual numbers
+func (h *HyperDual) Sub(x, y *HyperDual) *HyperDual {
+	return h.elementwise(x, y, (*Float).Sub)
+}
+
+// Neg negates a hyper-dual number
+func (h *HyperDual) Neg(x *HyperDual) *HyperDual {
+	return h.elementwise(x, x, func(f, a, _ *Float) *Float {
+		return f.Sub(f, a)
+	})
+}
+
+// Mul multiplies two hyper-dual numbers
+func (h *HyperDual) Mul(x, y *HyperDual) *HyperDual {
+	prec := h.prec()
+	product := func(a, b *Float) *Float {
+		return newFloat(prec).Mul(a, b)
+	}
+	b := product(x.A, y.B)
+	b.Add(b, product(x.B, y.A))
+	c := product(x.A, y.C)
+	c.Add(c, product(x.C, y.A))
+	d := product(x.A, y.D)
+	d.Add(d, product(x.B, y.C))
+	d.Add(d, product(x.C, y.B))
+	d.Add(d, product(x.D, y.A))
+	return h.set(product(x.A, y.A), b, c, d)
+}
+
+// chain sets h to f(x) for the value f and the first and second derivatives f1 and f2 of f at a
+func (h *HyperDual) chain(x *HyperDual, f, f1, f2 *Float) *HyperDual {
+	prec := h.prec()
+	b := newFloat(prec).Mul(f1, x.B)
+	c := newFloat(prec).Mul(f1, x.C)
+	d := newFloat(prec).Mul(f1, x.D)
+	bc := newFloat(prec).Mul(x.B, x.C)
+	d.Add(d, bc.Mul(bc, f2))
+	return h.set(f, b, c, d)
+}
+
+// Inv computes the reciprocal 1/x, with the derivatives -1/a^2 and 2/a^3
+func (h *HyperDual) Inv(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f := newFloat(prec).Div(newReal(1, prec), x.A)
+	f1 := newFloat(prec).Mul(f, f)
+	f2 := newFloat(prec).Mul(f1, f)
+	f2.Add(f2, f2)
+	return h.chain(x, f, f1.Sub(newFloat(prec), f1), f2)
+}
+
+// Div divides two hyper-dual numbers
+func (h *HyperDual) Div(x, y *HyperDual) *HyperDual {
+	i := newHyperDual(h.prec()).Inv(y)
+	return h.Mul(x, i)
+}
+
+// Exp computes e^x, with the derivatives e^a and e^a
+func (h *HyperDual) Exp(x *HyperDual) *HyperDual {
+	f := newFloat(h.prec()).Exp(x.A)
+	return h.chain(x, f, f, f)
+}
+
+// Log computes the natural log of x, with the derivatives 1/a and -1/a^2
+func (h *HyperDual) Log(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f1 := newFloat(prec).Div(newReal(1, prec), x.A)
+	f2 := newFloat(prec).Mul(f1, f1)
+	return h.chain(x, newFloat(prec).Log(x.A), f1, f2.Sub(newFloat(prec), f2))
+}
+
+// Sin computes the sine of x, with the derivatives cos(a) and -sin(a)
+func (h *HyperDual) Sin(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f := newFloat(prec).Sin(x.A)
+	return h.chain(x, f, newFloat(prec).Cos(x.A), newFloat(prec).Sub(newFloat(prec), f))
+}
+
+// Cos computes the cosine of x, with the derivatives -sin(a) and -cos(a)
+func (h *HyperDual) Cos(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f := newFloat(prec).Cos(x.A)
+	f1 := newFloat(prec).Sin(x.A)
+	return h.chain(x, f, f1.Sub(newFloat(prec), f1), newFloat(prec).Sub(newFloat(prec), f))
+}
+
+// Tan computes the tangent of x, with the derivatives 1 + tan(a)^2 and
+// 2 tan(a) (1 + tan(a)^2)
+func (h *HyperDual) Tan(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f := newFloat(prec).Tan(x.A)
+	f1 := newFloat(prec).Mul(f, f)
+	f1.Add(f1, newReal(1, prec))
+	f2 := newFloat(prec).Mul(f, f1)
+	return h.chain(x, f, f1, f2.Add(f2, f2))
+}
+
+// Sqrt computes the square root of x, with the derivatives 1/(2 sqrt(a)) and
+// -1/(4 a sqrt(a))
+func (h *HyperDual) Sqrt(x *HyperDual) *HyperDual {
+	prec := h.prec()
+	f := newFloat(prec).Sqrt(x.A)
+	f1 := newFloat(prec).Add(f, f)
+	f1.Div(newReal(1, prec), f1)
+	f2 := newFloat(prec).Div(f1, x.A)
+	f2.Div(f2, newReal(-2, prec))
+	return h.chain(x, f, f1, f2)
+}
+
+// Pow computes x**y = e^(y log(x))
+func (h *HyperDual) Pow(x, y *HyperDual) *HyperDual {
+	l := newHyperDual(h.prec()).Log(x)
+	l.Mul(l, y)
+	return h.Exp(l)
+}
+
+// String returns a string of the hyper-dual number
+func (h *HyperDual) String() string {
+	return "(" + h.A.String() + ") + (" + h.B.String() + ")e1 + (" + h.C.String() + ")e2 + (" +
+		h.D.String() + ")e1e2"
+}
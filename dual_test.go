@@ -0,0 +1,113 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// dualFunctions are the dual and hyper-dual functions and the matching float functions
+var dualFunctions = map[string]struct {
+	dual      func(d, x *Dual) *Dual
+	hyperDual func(h, x *HyperDual) *HyperDual
+	float     func(f, x *Float) *Float
+}{
+	"Exp":  {(*Dual).Exp, (*HyperDual).Exp, (*Float).Exp},
+	"Log":  {(*Dual).Log, (*HyperDual).Log, (*Float).Log},
+	"Sin":  {(*Dual).Sin, (*HyperDual).Sin, (*Float).Sin},
+	"Cos":  {(*Dual).Cos, (*HyperDual).Cos, (*Float).Cos},
+	"Tan":  {(*Dual).Tan, (*HyperDual).Tan, (*Float).Tan},
+	"Sqrt": {(*Dual).Sqrt, (*HyperDual).Sqrt, (*Float).Sqrt},
+	"Inv": {
+		func(d, x *Dual) *Dual { return d.Div(NewDual(newReal(1, 64), newFloat(64)), x) },
+		(*HyperDual).Inv,
+		func(f, x *Float) *Float { return f.Div(newReal(1, f.A.Prec()), x) },
+	},
+	"Cube": {
+		func(d, x *Dual) *Dual { return d.Pow(x, NewDual(newReal(3, 64), newFloat(64))) },
+		func(h, x *HyperDual) *HyperDual {
+			y := newHyperDual(64)
+			y.A.A.SetInt64(3)
+			return h.Pow(x, y)
+		},
+		func(f, x *Float) *Float { return f.Mul(x, newFloat(f.A.Prec()).Mul(x, x)) },
+	},
+}
+
+// dualClose determines if a and b differ by less than 2^-e relative to b
+func dualClose(a, b *Float, e int) bool {
+	d := newFloat(b.A.Prec()).Sub(a, b)
+	m := d.Modulus()
+	if m.Sign() == 0 {
+		return true
+	}
+	return m.MantExp(nil)-b.Modulus().MantExp(nil) < -e
+}
+
+func TestDual(t *testing.T) {
+	const prec = 256
+	x := NewFloat(big.NewFloat(.75).SetPrec(prec), big.NewFloat(-.5).SetPrec(prec))
+	// h is small enough for the differences to be accurate to 2^-2h
+	h := NewFloat(big.NewFloat(0).SetMantExp(big.NewFloat(1), -80).SetPrec(4*prec), big.NewFloat(0).SetPrec(4*prec))
+	for name, function := range dualFunctions {
+		d := function.dual(newDual(prec), Variable(x))
+		hd := function.hyperDual(newHyperDual(prec), HyperVariable(x))
+
+		f := func(dx *Float, sign int64) *Float {
+			y := newFloat(4*prec).Mul(dx, newReal(sign, 4*prec))
+			y.Add(y, x)
+			return function.float(newFloat(4*prec), y)
+		}
+		value := f(h, 0)
+		plus, minus := f(h, 1), f(h, -1)
+		// f'(x) = (f(x + h) - f(x - h))/2h + O(h^2)
+		derivative := newFloat(4*prec).Sub(plus, minus)
+		derivative.Div(derivative, newFloat(4*prec).Add(h, h))
+		// f''(x) = (f(x + h) - 2f(x) + f(x - h))/h^2 + O(h^2)
+		second := newFloat(4*prec).Add(plus, minus)
+		second.Sub(second, newFloat(4*prec).Add(value, value))
+		second.Div(second, newFloat(4*prec).Mul(h, h))
+
+		if !dualClose(d.A, value, prec-4) || !dualClose(hd.A, value, prec-4) {
+			t.Fatal("invalid value", name, d.String(), hd.String())
+		}
+		if !dualClose(d.B, derivative, 150) || !dualClose(hd.B, derivative, 150) || !dualClose(hd.C, derivative, 150) {
+			t.Fatal("invalid derivative", name, d.String(), hd.String(), derivative.String())
+		}
+		if !dualClose(hd.D, second, 150) {
+			t.Fatal("invalid second derivative", name, hd.String(), second.String())
+		}
+	}
+}
+
+func TestDual_Pow(t *testing.T) {
+	// d/dz z^z = z^z (log(z) + 1)
+	x := NewFloat(big.NewFloat(1.5).SetPrec(128), big.NewFloat(.25).SetPrec(128))
+	z := Variable(x)
+	d := newDual(128).Pow(z, z)
+	expected := newFloat(128).Log(x)
+	expected.Add(expected, newReal(1, 128))
+	expected.Mul(expected, newFloat(128).Pow(x, x))
+	if !dualClose(d.B, expected, 120) {
+		t.Fatal("invalid derivative", d.String(), expected.String())
+	}
+
+	// Newton's method for z^3 = 2 + i
+	c := NewFloat(big.NewFloat(2).SetPrec(128), big.NewFloat(1).SetPrec(128))
+	z = Variable(newReal(1, 128))
+	for i := 0; i < 12; i++ {
+		f := newDual(128).Mul(z, z)
+		f.Mul(f, z)
+		f.A.Sub(f.A, c)
+		step := newFloat(128).Div(f.A, f.B)
+		z = Variable(newFloat(128).Sub(z.A, step))
+	}
+	cube := newFloat(128).Mul(z.A, z.A)
+	cube.Mul(cube, z.A)
+	if !dualClose(cube, c, 124) {
+		t.Fatal("invalid root", z.A.String())
+	}
+}
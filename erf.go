@@ -0,0 +1,266 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// erfGuard is the number of guard bits used by the error functions
+const erfGuard = 32
+
+// norm64 computes the squared absolute value of z as a float64
+func norm64(z *Float) float64 {
+	a, _ := z.A.Float64()
+	b, _ := z.B.Float64()
+	return a*a + b*b
+}
+
+// erfLarge determines if the continued fraction should be used instead of the series
+func erfLarge(z *Float, prec uint) bool {
+	return norm64(z) >= float64(prec)*math.Ln2
+}
+
+// erfCancel computes the number of bits lost to cancellation in the series
+func erfCancel(z *Float) uint {
+	return uint(norm64(z)*math.Log2E) + 16
+}
+
+// erfSeries computes erf(z) = 2/sqrt(pi) sum (-1)^n z^(2n+1)/(n!(2n+1))
+// https://en.wikipedia.org/wiki/Error_function#Taylor_series
+func erfSeries(z *Float, prec uint) *Float {
+	wp := prec + erfCancel(z)
+	z = z.clone(wp)
+	zz := newFloat(wp)
+	zz.Mul(z, z)
+	zz.A.Neg(zz.A)
+	zz.B.Neg(zz.B)
+	eps := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -2*int(prec))
+	eps.Mul(eps, norm(z))
+
+	sum, term := z.clone(wp), z.clone(wp)
+	t, n := newFloat(wp), 1
+	for {
+		term.Mul(term, zz)
+		term.A.Quo(term.A, big.NewFloat(float64(n)).SetPrec(wp))
+		term.B.Quo(term.B, big.NewFloat(float64(n)).SetPrec(wp))
+		d := big.NewFloat(float64(2*n + 1)).SetPrec(wp)
+		t.A.Quo(term.A, d)
+		t.B.Quo(term.B, d)
+		sum.Add(sum, t)
+		if norm(t).Cmp(eps) < 0 {
+			break
+		}
+		n++
+	}
+
+	c := big.NewFloat(2).SetPrec(wp)
+	c.Quo(c, bigfloat.Sqrt(bigfloat.PI(wp)))
+	sum.A.Mul(sum.A, c)
+	sum.B.Mul(sum.B, c)
+	return sum
+}
+
+// faddeevaSeries computes w(z) = e^(-z^2) + 2iz/sqrt(pi) sum (-2z^2)^n/(2n+1)!!
+func faddeevaSeries(z *Float, prec uint) *Float {
+	wp := prec + erfCancel(z)
+	z = z.clone(wp)
+	zz := newFloat(wp)
+	zz.Mul(z, z)
+	zz.A.Neg(zz.A)
+	zz.B.Neg(zz.B)
+	eps := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -2*int(prec+8))
+	peak := norm64(z)
+
+	sum, term := NewFloat(big.NewFloat(1).SetPrec(wp), big.NewFloat(0).SetPrec(wp)), newFloat(wp)
+	term.A.SetInt64(1)
+	two := NewFloat(big.NewFloat(2).SetPrec(wp), big.NewFloat(0).SetPrec(wp))
+	two.Mul(two, zz)
+	for n := 1; ; n++ {
+		term.Mul(term, two)
+		d := big.NewFloat(float64(2*n + 1)).SetPrec(wp)
+		term.A.Quo(term.A, d)
+		term.B.Quo(term.B, d)
+		sum.Add(sum, term)
+		if float64(n) > peak && norm(term).Cmp(eps) < 0 {
+			break
+		}
+	}
+
+	c := big.NewFloat(2).SetPrec(wp)
+	c.Quo(c, bigfloat.Sqrt(bigfloat.PI(wp)))
+	iz := NewFloat(big.NewFloat(0).SetPrec(wp).Neg(z.B), big.NewFloat(0).SetPrec(wp).Set(z.A))
+	iz.A.Mul(iz.A, c)
+	iz.B.Mul(iz.B, c)
+	sum.Mul(sum, iz)
+	zz = exp(zz, wp)
+	return sum.Add(sum, zz)
+}
+
+// faddeevaFraction computes w(z) for Im(z) >= 0 with the Laplace continued fraction
+// w(z) = i/sqrt(pi) 1/(z - (1/2)/(z - 1/(z - (3/2)/(z - ...))))
+// evaluated with the modified Lentz algorithm
+// https://en.wikipedia.org/wiki/Faddeeva_function
+func faddeevaFraction(z *Float, prec uint) *Float {
+	wp := prec + erfGuard
+	z = z.clone(wp)
+	tiny := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -4*int(wp))
+	eps := big.NewFloat(0).SetPrec(wp).SetMantExp(big.NewFloat(1).SetPrec(wp), -2*int(prec+8))
+	one := big.NewFloat(1).SetPrec(wp)
+
+	f, c, d := z.clone(wp), z.clone(wp), newFloat(wp)
+	a, delta := big.NewFloat(0).SetPrec(wp), newFloat(wp)
+	for n := 1; ; n++ {
+		a.SetFloat64(-float64(n) / 2)
+		d.A.Mul(d.A, a)
+		d.B.Mul(d.B, a)
+		d.Add(d, z)
+		if d.A.Sign() == 0 && d.B.Sign() == 0 {
+			d.A.Set(tiny)
+		}
+		c.inv(c)
+		c.A.Mul(c.A, a)
+		c.B.Mul(c.B, a)
+		c.Add(c, z)
+		if c.A.Sign() == 0 && c.B.Sign() == 0 {
+			c.A.Set(tiny)
+		}
+		d.inv(d)
+		delta.Mul(c, d)
+		f.Mul(f, delta)
+		delta.A.Sub(delta.A, one)
+		if norm(delta).Cmp(eps) < 0 {
+			break
+		}
+	}
+
+	s := bigfloat.Sqrt(bigfloat.PI(wp))
+	f.A.Mul(f.A, s)
+	f.B.Mul(f.B, s)
+	f.inv(f)
+	f.A, f.B = f.B.Neg(f.B), f.A
+	return f
+}
+
+// faddeeva computes w(z) = e^(-z^2) erfc(-iz)
+func faddeeva(z *Float, prec uint) *Float {
+	if z.B.Sign() < 0 {
+		// w(z) = 2e^(-z^2) - w(-z)
+		wp := prec + erfGuard
+		y := z.clone(wp)
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+		w := faddeeva(y, wp)
+		y.Mul(y, y)
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+		y = exp(y, wp)
+		y.Add(y, y)
+		return y.Sub(y, w)
+	}
+	if erfLarge(z, prec) {
+		w := faddeevaFraction(z, prec)
+		if z.B.Sign() == 0 {
+			// on the real axis Re(w(x)) = e^(-x^2)
+			x := big.NewFloat(0).SetPrec(prec + erfGuard)
+			x.Mul(z.A, z.A)
+			w.A.Set(bigfloat.Exp(x.Neg(x)))
+		}
+		return w
+	}
+	return faddeevaSeries(z, prec)
+}
+
+// erfc computes erfc(z) = e^(-z^2) w(iz) for Re(z) >= 0 and 2 - erfc(-z) otherwise
+func erfc(z *Float, prec uint) *Float {
+	wp := prec + erfGuard
+	if z.A.Sign() < 0 {
+		y := z.clone(wp)
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+		y = erfc(y, wp)
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+		y.A.Add(y.A, big.NewFloat(2).SetPrec(wp))
+		return y
+	}
+	iz := NewFloat(big.NewFloat(0).SetPrec(wp).Neg(z.B), big.NewFloat(0).SetPrec(wp).Set(z.A))
+	w := faddeeva(iz, wp)
+	y := z.clone(wp)
+	y.Mul(y, y)
+	y.A.Neg(y.A)
+	y.B.Neg(y.B)
+	y = exp(y, wp)
+	return w.Mul(w, y)
+}
+
+// erf computes erf(z) with the series for small z and 1 - erfc(z) for large z
+func erf(z *Float, prec uint) *Float {
+	if z.A.Sign() == 0 && z.B.Sign() == 0 {
+		return newFloat(prec)
+	}
+	if !erfLarge(z, prec) {
+		// a part of erf(z) can be as small as e^(-|z|^2) when the other is near 1, so it needs
+		// |z|^2 log2(e) more bits, which are fewer than prec below the threshold
+		return erfSeries(z, prec+uint(norm64(z)*math.Log2E))
+	}
+	wp := prec + erfGuard
+	y := z.clone(wp)
+	negative := y.A.Sign() < 0
+	if negative {
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+	}
+	y = erfc(y, wp)
+	y.A.Sub(big.NewFloat(1).SetPrec(wp), y.A)
+	y.B.Neg(y.B)
+	if negative {
+		y.A.Neg(y.A)
+		y.B.Neg(y.B)
+	}
+	return y
+}
+
+// Erf computes the error function of x
+// https://en.wikipedia.org/wiki/Error_function
+func (f *Float) Erf(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		y := erf(x, f.A.Prec()+erfGuard)
+		return f.set(y, x.A.Sign() == 0, x.B.Sign() == 0)
+	})
+}
+
+// Erfc computes the complementary error function of x
+// https://en.wikipedia.org/wiki/Error_function#Complementary_error_function
+func (f *Float) Erfc(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		y := erfc(x, f.A.Prec()+erfGuard)
+		return f.set(y, false, x.B.Sign() == 0)
+	})
+}
+
+// Erfi computes the imaginary error function of x, erfi(x) = -i erf(ix)
+// https://en.wikipedia.org/wiki/Error_function#Imaginary_error_function
+func (f *Float) Erfi(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		prec := f.A.Prec() + erfGuard
+		ix := NewFloat(big.NewFloat(0).SetPrec(prec).Neg(x.B), big.NewFloat(0).SetPrec(prec).Set(x.A))
+		y := erf(ix, prec)
+		y.A, y.B = y.B, y.A.Neg(y.A)
+		return f.set(y, x.A.Sign() == 0, x.B.Sign() == 0)
+	})
+}
+
+// Faddeeva computes the Faddeeva function w(x) = e^(-x^2) erfc(-ix)
+// https://en.wikipedia.org/wiki/Faddeeva_function
+func (f *Float) Faddeeva(x *Float) *Float {
+	return f.unary(x, undefined, func() *Float {
+		y := faddeeva(x, f.A.Prec()+erfGuard)
+		return f.set(y, false, x.A.Sign() == 0)
+	})
+}
@@ -0,0 +1,148 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestFloat_Erf(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.Erf(a)
+	t.Log(a.String())
+	if a.String() != "0.8427007929" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Erf(a)
+	t.Log(a.String())
+	if a.String() != "1.316151282 + 0.1904534692i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Erf(a)
+	t.Log(a.String())
+	if a.String() != "0 + 1.650425759i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-4).SetPrec(64), big.NewFloat(2).SetPrec(64))
+	a.Erf(a)
+	t.Log(a.String())
+	if a.String() != "-1.000000565 + -5.131005296e-07i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(3).SetPrec(64), big.NewFloat(10).SetPrec(64))
+	a.Erf(a)
+	t.Log(a.String())
+	if a.String() != "-2.826768466e+36 + -1.799401904e+38i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Erfc(t *testing.T) {
+	a := NewFloat(big.NewFloat(2).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.Erfc(a)
+	t.Log(a.String())
+	if a.String() != "0.004677734981" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(7).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.Erfc(a)
+	t.Log(a.String())
+	if a.String() != "4.183825608e-23" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(10).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Erfc(a)
+	t.Log(a.String())
+	if a.String() != "1.786012092e-45 + -5.359995111e-45i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(10).SetPrec(64))
+	a.Erfc(a)
+	t.Log(a.String())
+	if a.String() != "1 + -1.524307423e+42i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_Erfi(t *testing.T) {
+	a := NewFloat(big.NewFloat(2).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.Erfi(a)
+	t.Log(a.String())
+	if a.String() != "18.56480241" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Erfi(a)
+	t.Log(a.String())
+	if a.String() != "0.1904534692 + 1.316151282i" {
+		t.Fatal("invalid result")
+	}
+
+	// the real part is about 1e-14 of the imaginary part
+	a = NewFloat(big.NewFloat(-0.05080768417).SetPrec(64), big.NewFloat(-5.319259522).SetPrec(64))
+	b := newFloat(1024).Erfi(a)
+	a.Erfi(a)
+	if a.A.Cmp(b.A.SetPrec(64)) != 0 || a.B.Cmp(b.B.SetPrec(64)) != 0 {
+		t.Fatal("invalid result", a.A.Text('g', 25), b.A.Text('g', 25))
+	}
+}
+
+func TestFloat_Faddeeva(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.Faddeeva(a)
+	t.Log(a.String())
+	if a.String() != "0.3678794412 + 0.6071577058i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Faddeeva(a)
+	t.Log(a.String())
+	if a.String() != "0.3047442053 + 0.2082189382i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(0).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.Faddeeva(a)
+	t.Log(a.String())
+	if a.String() != "0.4275835762" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(.5).SetPrec(64), big.NewFloat(-3).SetPrec(64))
+	a.Faddeeva(a)
+	t.Log(a.String())
+	if a.String() != "-12495.24286 + 1781.15535i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(20).SetPrec(64), big.NewFloat(.5).SetPrec(64))
+	a.Faddeeva(a)
+	t.Log(a.String())
+	if a.String() != "0.0007074522199 + 0.0282271209i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_FaddeevaPrecision(t *testing.T) {
+	a := NewFloat(big.NewFloat(3).SetPrec(256), big.NewFloat(10).SetPrec(256))
+	b := NewFloat(big.NewFloat(3).SetPrec(1024), big.NewFloat(10).SetPrec(1024))
+	a.Faddeeva(a)
+	b.Faddeeva(b)
+	if a.A.Text('g', 70) != b.A.Text('g', 70) || a.B.Text('g', 70) != b.B.Text('g', 70) {
+		t.Fatal("continued fraction and series disagree", a.A.Text('g', 70), b.A.Text('g', 70))
+	}
+}
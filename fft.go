@@ -0,0 +1,283 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// fftGuard is the number of guard bits used by the FFT in addition to 2 log2(n) bits for the
+// error that grows with the length n
+const fftGuard = 32
+
+// fftSmall is the largest prime factor of a length that is transformed directly instead of
+// with Bluestein's algorithm
+const fftSmall = 16
+
+// fftTables is the number of tables of roots of unity that are cached, which covers the
+// lengths n, 2n and a power of two of a transform with Bluestein's algorithm
+const fftTables = 4
+
+// fftTable is a cached table of the roots of unity of a length and precision
+type fftTable struct {
+	n     int
+	prec  uint
+	table []*Float
+}
+
+var (
+	fftMutex    sync.Mutex
+	fftTwiddles []fftTable
+)
+
+// twiddles computes the roots of unity e^(-2 pi i k/n) for k < n with the given precision,
+// the most recently used tables are cached and must not be changed
+func twiddles(n int, prec uint) []*Float {
+	fftMutex.Lock()
+	defer fftMutex.Unlock()
+	for i, cached := range fftTwiddles {
+		if cached.n == n && cached.prec == prec {
+			copy(fftTwiddles[1:i+1], fftTwiddles[:i])
+			fftTwiddles[0] = cached
+			return cached.table
+		}
+	}
+	wp := prec + 16 + uint(bits.Len(uint(n)))
+	pi := bigfloat.PI(wp)
+	table := make([]*Float, n)
+	for k := 0; 2*k <= n; k++ {
+		// the angle 2 pi k/n is in [0, pi] and e^(-2 pi i (n - k)/n) = conj(e^(-2 pi i k/n))
+		angle := big.NewFloat(0).SetPrec(wp).Mul(pi, big.NewFloat(float64(2*k)))
+		angle.Quo(angle, big.NewFloat(float64(n)))
+		sin, cos := sinCos(angle, prec)
+		if 4*k%n == 0 {
+			// the quarter turns are exact
+			sin.SetInt64([]int64{0, 1, 0}[4*k/n])
+			cos.SetInt64([]int64{1, 0, -1}[4*k/n])
+		}
+		if sin.Sign() != 0 {
+			sin.Neg(sin)
+		}
+		table[k] = NewFloat(cos, sin)
+		if k > 0 && 2*k < n {
+			table[n-k] = NewFloat(big.NewFloat(0).SetPrec(prec).Set(cos), big.NewFloat(0).SetPrec(prec).Neg(sin))
+		}
+	}
+	fftTwiddles = append([]fftTable{{n: n, prec: prec, table: table}}, fftTwiddles...)
+	if len(fftTwiddles) > fftTables {
+		fftTwiddles = fftTwiddles[:fftTables]
+	}
+	return table
+}
+
+// smallestFactor computes the smallest prime factor of n > 1
+func smallestFactor(n int) int {
+	for p := 2; p*p <= n; p++ {
+		if n%p == 0 {
+			return p
+		}
+	}
+	return n
+}
+
+// transform computes the discrete Fourier transform of x with the given precision and the
+// roots of unity of a multiple of the length of x, with the conjugate roots for the inverse.
+// A length n = pm with the smallest prime factor p is split into p transforms of length m,
+// which are combined with a butterfly for p = 2 and a direct transform of length p otherwise.
+// If p is larger than fftSmall, the whole length is transformed with Bluestein's algorithm
+// instead, so the direct transforms cost at most fftSmall operations for each entry
+// https://en.wikipedia.org/wiki/Cooley%E2%80%93Tukey_FFT_algorithm
+func transform(x []*Float, table []*Float, inverse bool, prec uint) []*Float {
+	n := len(x)
+	if n == 1 {
+		return []*Float{x[0].clone(prec)}
+	}
+	step := len(table) / n
+	root := func(k int) *Float {
+		k %= n
+		if inverse && k != 0 {
+			k = n - k
+		}
+		return table[k*step]
+	}
+	p := smallestFactor(n)
+	if p > fftSmall {
+		return bluestein(x, inverse, prec)
+	}
+	m := n / p
+	subs := make([][]*Float, p)
+	for j := range subs {
+		s := make([]*Float, m)
+		for k := range s {
+			s[k] = x[j+p*k]
+		}
+		subs[j] = transform(s, table, inverse, prec)
+	}
+	y := make([]*Float, n)
+	t := newFloat(prec)
+	if p == 2 {
+		for k := 0; k < m; k++ {
+			t.Mul(subs[1][k], root(k))
+			y[k] = newFloat(prec).Add(subs[0][k], t)
+			y[k+m] = newFloat(prec).Sub(subs[0][k], t)
+		}
+		return y
+	}
+	for i := range y {
+		k := i % m
+		y[i] = subs[0][k].clone(prec)
+		for j := 1; j < p; j++ {
+			t.Mul(subs[j][k], root(j*i))
+			y[i].Add(y[i], t)
+		}
+	}
+	return y
+}
+
+// bluestein computes the discrete Fourier transform of x with the given precision from the
+// convolution of x_j c_j with conj(c_j), where c_j = e^(-pi i j^2/n), which is computed with
+// transforms of a power of two length
+// https://en.wikipedia.org/wiki/Chirp_Z-transform#Bluestein's_algorithm
+func bluestein(x []*Float, inverse bool, prec uint) []*Float {
+	n := len(x)
+	m := 1 << bits.Len(uint(2*n-2))
+	chirps := twiddles(2*n, prec)
+	chirp := func(j int) *Float {
+		k := j * j % (2 * n)
+		if inverse && k != 0 {
+			k = 2*n - k
+		}
+		return chirps[k]
+	}
+	a, b := make([]*Float, m), make([]*Float, m)
+	for j := range a {
+		a[j], b[j] = newFloat(prec), newFloat(prec)
+	}
+	for j := 0; j < n; j++ {
+		a[j].Mul(x[j], chirp(j))
+		b[j].Conj(chirp(j))
+		if j > 0 {
+			b[m-j].Conj(chirp(j))
+		}
+	}
+	table := twiddles(m, prec)
+	a, b = transform(a, table, false, prec), transform(b, table, false, prec)
+	for j := range a {
+		a[j].Mul(a[j], b[j])
+	}
+	a = transform(a, table, true, prec)
+	y := make([]*Float, n)
+	for k := range y {
+		y[k] = newFloat(prec).Mul(a[k], chirp(k))
+		// the inverse transform of length m is scaled by 1/m exactly
+		y[k].A.SetMantExp(y[k].A, -bits.TrailingZeros(uint(m)))
+		y[k].B.SetMantExp(y[k].B, -bits.TrailingZeros(uint(m)))
+	}
+	return y
+}
+
+// fourier computes the discrete Fourier transform of x or its inverse scaled by 1/n, rounded
+// to the context. The parts of the result that are smaller than the resolution
+// 2^(1-prec) sum |Re x_j| + |Im x_j| of the context, divided by n for the inverse, are zero
+func (c *Context) fourier(x []*Float, inverse bool) []*Float {
+	n := len(x)
+	if n == 0 {
+		return nil
+	}
+	p := c.Prec
+	resolution := big.NewFloat(0).SetPrec(64)
+	for _, v := range x {
+		for _, part := range []*big.Float{v.A, v.B} {
+			if part.Prec() > p {
+				p = part.Prec()
+			}
+			resolution.Add(resolution, big.NewFloat(0).SetPrec(part.Prec()).Abs(part))
+		}
+	}
+	resolution.SetMantExp(resolution, 1-int(c.Prec))
+	wp := p + fftGuard + 2*uint(bits.Len(uint(n)))
+
+	y := transform(x, twiddles(n, wp), inverse, wp)
+	if inverse {
+		scale := big.NewFloat(0).SetPrec(wp).Quo(big.NewFloat(1), big.NewFloat(float64(n)))
+		resolution.Mul(resolution, scale)
+		for _, v := range y {
+			v.A.Mul(v.A, scale)
+			v.B.Mul(v.B, scale)
+		}
+	}
+	for i, v := range y {
+		for _, part := range []*big.Float{v.A, v.B} {
+			if big.NewFloat(0).SetPrec(part.Prec()).Abs(part).Cmp(resolution) <= 0 {
+				part.SetInt64(0)
+			}
+		}
+		y[i] = c.NewFloat(v.A, v.B)
+	}
+	return y
+}
+
+// FFT computes the discrete Fourier transform X_k = sum x_j e^(-2 pi i jk/n) of x rounded to
+// the context, with radix 2 and mixed radix transforms and Bluestein's algorithm for a
+// large prime length. The transform is computed with guard bits that grow with the length,
+// and a part of X_k that is smaller than the resolution 2^(1-prec) sum |Re x_j| + |Im x_j| of
+// the context is zero. If the context of the spectrum has 64 + 2 log2(n) more bits than the
+// entries of x, InverseFFT of the spectrum in the context of x is exactly x, except for the
+// parts smaller than 2^(3-prec) n times the largest part of x, which are zero
+// https://en.wikipedia.org/wiki/Fast_Fourier_transform
+func (c *Context) FFT(x []*Float) []*Float {
+	return c.fourier(x, false)
+}
+
+// InverseFFT computes the inverse discrete Fourier transform x_j = 1/n sum X_k e^(2 pi i jk/n)
+// of X rounded to the context, a part of x_j that is smaller than the resolution
+// 2^(1-prec)/n sum |Re X_k| + |Im X_k| of the context is zero
+func (c *Context) InverseFFT(x []*Float) []*Float {
+	return c.fourier(x, true)
+}
+
+// rowVector returns the entries of the single row matrix a as Float numbers with the
+// precision of a, it panics if a has more than one row
+func rowVector(a *Matrix) []*Float {
+	if len(a.Values) != 1 {
+		panic("non row matrix")
+	}
+	var x []*Float
+	for i := range a.Values[0] {
+		v := a.Context().NewFloat(nil, nil)
+		v.SetRat(&a.Values[0][i])
+		x = append(x, v)
+	}
+	return x
+}
+
+// setVector sets m to the single row matrix with the exact values of x
+func (m *Matrix) setVector(x []*Float) *Matrix {
+	row := make([]Rational, len(x))
+	for i, v := range x {
+		r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+		v.Rat(r)
+		row[i] = *r
+	}
+	m.Values = [][]Rational{row}
+	return m
+}
+
+// FFT sets m to the discrete Fourier transform of the single row matrix a, the entries of a
+// are rounded to the precision of a and the transform is rounded to the precision of m
+func (m *Matrix) FFT(a *Matrix) *Matrix {
+	return m.setVector(m.Context().FFT(rowVector(a)))
+}
+
+// InverseFFT sets m to the inverse discrete Fourier transform of the single row matrix a, the
+// entries of a are rounded to the precision of a and the transform is rounded to the
+// precision of m
+func (m *Matrix) InverseFFT(a *Matrix) *Matrix {
+	return m.setVector(m.Context().InverseFFT(rowVector(a)))
+}
@@ -0,0 +1,138 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/bits"
+	"math/rand"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// fftVector creates a vector of random entries with the given precision, every third entry
+// has a zero part
+func fftVector(rng *rand.Rand, n int, prec uint) []*Float {
+	x := make([]*Float, n)
+	for i := range x {
+		x[i] = newFloat(prec)
+		x[i].A.SetFloat64(rng.NormFloat64())
+		x[i].A.SetMantExp(x[i].A, 0).Add(x[i].A, big.NewFloat(0).SetMantExp(big.NewFloat(rng.Float64()), -60))
+		if i%3 != 0 {
+			x[i].B.SetFloat64(rng.NormFloat64())
+		}
+	}
+	return x
+}
+
+// dft computes the discrete Fourier transform of x directly with the given precision
+func dft(x []*Float, prec uint) []*Float {
+	n := len(x)
+	pi := bigfloat.PI(prec + 16)
+	y := make([]*Float, n)
+	t := newFloat(prec)
+	for k := range y {
+		y[k] = newFloat(prec)
+		for j := range x {
+			angle := big.NewFloat(0).SetPrec(prec+16).Mul(pi, big.NewFloat(float64(-2*(j*k%n))))
+			angle.Quo(angle, big.NewFloat(float64(n)))
+			sin, cos := sinCos(angle, prec)
+			y[k].Add(y[k], t.Mul(x[j], NewFloat(cos, sin)))
+		}
+	}
+	return y
+}
+
+func TestContext_FFT(t *testing.T) {
+	c := NewContext(64, big.ToNearestEven)
+	var x []*Float
+	for i := int64(1); i <= 4; i++ {
+		x = append(x, newReal(i, 64))
+	}
+	y := c.FFT(x)
+	expected := []string{"10", "-2 + 2i", "-2", "-2 + -2i"}
+	for i := range y {
+		if y[i].String() != expected[i] {
+			t.Fatal("invalid transform", i, y[i].String())
+		}
+	}
+
+	// the smallest factor of 17 19 is transformed with Bluestein's algorithm for the whole length
+	x = x[:0]
+	for i := 0; i < 17*19; i++ {
+		x = append(x, newReal(1, 64))
+	}
+	y = c.FFT(x)
+	for i := range y {
+		if i == 0 && y[i].String() != "323" || i > 0 && !y[i].isZero() {
+			t.Fatal("invalid transform", i, y[i].String())
+		}
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	const prec = 128
+	c = NewContext(prec, big.ToNearestEven)
+	for _, n := range []int{1, 2, 7, 12, 15, 16, 17, 30, 34, 101} {
+		x := fftVector(rng, n, prec)
+		y, z := c.FFT(x), dft(x, 2*prec)
+		bound := big.NewFloat(0)
+		for _, v := range x {
+			bound.Add(bound, modulus(v, 64))
+		}
+		bound.SetMantExp(bound, 2-prec)
+		for k := range y {
+			if d := modulus(newFloat(2*prec).Sub(y[k], z[k]), 64); d.Cmp(bound) > 0 {
+				t.Fatal("invalid transform", n, k, d.String())
+			}
+		}
+	}
+}
+
+func TestContext_InverseFFT(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const prec = 128
+	c := NewContext(prec, big.ToNearestEven)
+	for _, n := range []int{1, 3, 12, 16, 17, 30, 64, 97, 323} {
+		x := fftVector(rng, n, prec)
+		spectrum := NewContext(prec+64+2*uint(bits.Len(uint(n))), big.ToNearestEven)
+		y := c.InverseFFT(spectrum.FFT(x))
+		for i := range x {
+			if x[i].A.Cmp(y[i].A) != 0 || x[i].B.Cmp(y[i].B) != 0 {
+				t.Fatal("invalid round trip", n, i, x[i].String(), y[i].String())
+			}
+		}
+	}
+}
+
+func TestTwiddles(t *testing.T) {
+	a, b := twiddles(24, 100), twiddles(24, 100)
+	if &a[0] != &b[0] {
+		t.Fatal("the twiddle factors aren't cached")
+	}
+	if a[6].String() != "0 + -1i" || a[12].String() != "-1" {
+		t.Fatal("invalid twiddle factors", a[6].String(), a[12].String())
+	}
+	for n := 1; n <= 64; n++ {
+		twiddles(n, 100)
+	}
+	if len(fftTwiddles) > fftTables {
+		t.Fatal("the twiddle factors aren't bounded", len(fftTwiddles))
+	}
+}
+
+func TestMatrix_FFT(t *testing.T) {
+	a := rationalMatrix(64, 1, []int64{1, 0, 2, 0, 3, 0, 4, 0, 5, 0})
+	spectrum := NewMatrix(256)
+	spectrum.FFT(&a)
+	b := NewMatrix(64)
+	b.InverseFFT(&spectrum)
+	if b.String() != a.String() {
+		t.Fatal("invalid round trip", b.String())
+	}
+	if s := spectrum.Values[0][0]; s.A.Cmp(big.NewRat(15, 1)) != 0 || s.B.Sign() != 0 {
+		t.Fatal("invalid transform", spectrum.String())
+	}
+}
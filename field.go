@@ -0,0 +1,474 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Field is the arithmetic of a field with elements of type T, the methods return new
+// elements and never change their operands
+// https://en.wikipedia.org/wiki/Field_(mathematics)
+type Field[T any] interface {
+	Zero() T
+	One() T
+	Add(a, b T) T
+	Mul(a, b T) T
+	Neg(a T) T
+	// Inv computes the multiplicative inverse of a non zero element
+	Inv(a T) T
+	IsZero(a T) bool
+}
+
+// Normed is a field with an absolute value, the elimination of a Dense matrix over a
+// normed field picks the pivot with the largest absolute value
+// https://en.wikipedia.org/wiki/Pivot_element#Partial,_rook,_and_complete_pivoting
+type Normed[T any] interface {
+	Field[T]
+	Magnitude(a T) *big.Float
+}
+
+// RationalField is the field of the Rational numbers
+type RationalField struct{}
+
+// Zero returns 0
+func (RationalField) Zero() *Rational {
+	return NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+}
+
+// One returns 1
+func (RationalField) One() *Rational {
+	return NewRational(big.NewRat(1, 1), big.NewRat(0, 1))
+}
+
+// Add computes a + b
+func (f RationalField) Add(a, b *Rational) *Rational {
+	return f.Zero().Add(a, b)
+}
+
+// Mul computes a * b
+func (f RationalField) Mul(a, b *Rational) *Rational {
+	return f.Zero().Mul(a, b)
+}
+
+// Neg computes -a
+func (f RationalField) Neg(a *Rational) *Rational {
+	return f.Zero().Neg(a)
+}
+
+// Inv computes 1/a
+func (f RationalField) Inv(a *Rational) *Rational {
+	return f.Zero().Div(f.One(), a)
+}
+
+// IsZero determines if a is 0
+func (RationalField) IsZero(a *Rational) bool {
+	return a.A.Sign() == 0 && a.B.Sign() == 0
+}
+
+// FloatField is the field of the Float numbers rounded to a context
+type FloatField struct {
+	Context *Context
+}
+
+// NewFloatField creates a new field of the Float numbers with the precision and rounding mode
+func NewFloatField(prec uint, mode big.RoundingMode) FloatField {
+	return FloatField{
+		Context: NewContext(prec, mode),
+	}
+}
+
+// Zero returns 0
+func (f FloatField) Zero() *Float {
+	return f.Context.NewFloat(nil, nil)
+}
+
+// One returns 1
+func (f FloatField) One() *Float {
+	return f.Context.NewFloat(big.NewFloat(1), nil)
+}
+
+// Add computes a + b
+func (f FloatField) Add(a, b *Float) *Float {
+	return f.Zero().Add(a, b)
+}
+
+// Mul computes a * b
+func (f FloatField) Mul(a, b *Float) *Float {
+	return f.Zero().Mul(a, b)
+}
+
+// Neg computes -a, the signs of zero parts are negated
+func (f FloatField) Neg(a *Float) *Float {
+	z := f.Zero()
+	return z.unary(a, infinite, func() *Float {
+		z.A.Neg(a.A)
+		z.B.Neg(a.B)
+		return z
+	})
+}
+
+// Inv computes 1/a
+func (f FloatField) Inv(a *Float) *Float {
+	return f.Zero().Div(f.One(), a)
+}
+
+// IsZero determines if a is 0
+func (FloatField) IsZero(a *Float) bool {
+	return a.isZero()
+}
+
+// Magnitude computes |a|
+func (f FloatField) Magnitude(a *Float) *big.Float {
+	return f.Zero().Abs(a).A
+}
+
+// ModularField is the field of the integers modulo a prime
+// https://en.wikipedia.org/wiki/Finite_field
+type ModularField struct {
+	Modulus *big.Int
+}
+
+// NewModularField creates a new field of the integers modulo the prime p
+func NewModularField(p *big.Int) ModularField {
+	if !p.ProbablyPrime(32) {
+		panic("modulus isn't prime")
+	}
+	return ModularField{
+		Modulus: big.NewInt(0).Set(p),
+	}
+}
+
+// Zero returns 0
+func (ModularField) Zero() *big.Int {
+	return big.NewInt(0)
+}
+
+// One returns 1
+func (ModularField) One() *big.Int {
+	return big.NewInt(1)
+}
+
+// Add computes a + b mod p
+func (f ModularField) Add(a, b *big.Int) *big.Int {
+	c := big.NewInt(0).Add(a, b)
+	return c.Mod(c, f.Modulus)
+}
+
+// Mul computes a * b mod p
+func (f ModularField) Mul(a, b *big.Int) *big.Int {
+	c := big.NewInt(0).Mul(a, b)
+	return c.Mod(c, f.Modulus)
+}
+
+// Neg computes -a mod p
+func (f ModularField) Neg(a *big.Int) *big.Int {
+	c := big.NewInt(0).Neg(a)
+	return c.Mod(c, f.Modulus)
+}
+
+// Inv computes 1/a mod p
+func (f ModularField) Inv(a *big.Int) *big.Int {
+	c := big.NewInt(0).Mod(a, f.Modulus)
+	if c.Sign() == 0 {
+		panic("division by zero")
+	}
+	return c.ModInverse(c, f.Modulus)
+}
+
+// IsZero determines if a is 0 mod p
+func (f ModularField) IsZero(a *big.Int) bool {
+	return big.NewInt(0).Mod(a, f.Modulus).Sign() == 0
+}
+
+// Dense is a dense matrix over a field, the receiver of a method may alias the operands,
+// which are never changed
+type Dense[T any] struct {
+	Field  Field[T]
+	Values [][]T
+}
+
+// NewDense makes a new zero matrix over the field with the given number of rows and columns
+func NewDense[T any](field Field[T], rows, columns int) Dense[T] {
+	d := Dense[T]{
+		Field: field,
+	}
+	for i := 0; i < rows; i++ {
+		row := make([]T, columns)
+		for j := range row {
+			row[j] = field.Zero()
+		}
+		d.Values = append(d.Values, row)
+	}
+	return d
+}
+
+// Identity makes a new identity matrix over the field with the given size
+func Identity[T any](field Field[T], size int) Dense[T] {
+	d := NewDense(field, size, size)
+	for i := 0; i < size; i++ {
+		d.Values[i][i] = field.One()
+	}
+	return d
+}
+
+// Dense converts m to a dense matrix over the Rational numbers
+func (m *Matrix) Dense() Dense[*Rational] {
+	d := Dense[*Rational]{
+		Field: RationalField{},
+	}
+	for _, a := range m.Values {
+		var row []*Rational
+		for i := range a {
+			row = append(row, a[i].copy())
+		}
+		d.Values = append(d.Values, row)
+	}
+	return d
+}
+
+// SetDense sets m to the dense matrix a over the Rational numbers
+func (m *Matrix) SetDense(a *Dense[*Rational]) *Matrix {
+	values := [][]Rational{}
+	for _, a := range a.Values {
+		var row []Rational
+		for i := range a {
+			row = append(row, *a[i].copy())
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// Det computes the exact determinant of a square matrix
+func (m *Matrix) Det() *Rational {
+	d := m.Dense()
+	return d.Det()
+}
+
+// Inv computes the exact inverse of a square matrix, it panics if a is singular
+func (m *Matrix) Inv(a *Matrix) *Matrix {
+	d := a.Dense()
+	d.Inv(&d)
+	return m.SetDense(&d)
+}
+
+// Solve computes the exact solution x of a x = b for a square matrix a, it panics if a is singular
+func (m *Matrix) Solve(a, b *Matrix) *Matrix {
+	x, y := a.Dense(), b.Dense()
+	x.Solve(&x, &y)
+	return m.SetDense(&x)
+}
+
+// set copies a
+func (d *Dense[T]) set(a T) T {
+	return d.Field.Add(d.Field.Zero(), a)
+}
+
+// singular determines if d is 1x1
+func (d *Dense[T]) singular() bool {
+	return len(d.Values) == 1 && len(d.Values[0]) == 1
+}
+
+// elementwise applies the function to the entries of a and b,
+// a 1x1 matrix is applied to every entry of the other matrix
+func (d *Dense[T]) elementwise(a, b *Dense[T], function func(x, y T) T) *Dense[T] {
+	rows := a
+	if a.singular() {
+		rows = b
+	}
+	entry := func(x *Dense[T], i, j int) T {
+		if x.singular() {
+			return x.Values[0][0]
+		}
+		return x.Values[i][j]
+	}
+	values := [][]T{}
+	for i := range rows.Values {
+		var row []T
+		for j := range rows.Values[i] {
+			row = append(row, function(entry(a, i, j), entry(b, i, j)))
+		}
+		values = append(values, row)
+	}
+	d.Field, d.Values = a.Field, values
+	return d
+}
+
+// Add adds two matricies
+func (d *Dense[T]) Add(a, b *Dense[T]) *Dense[T] {
+	return d.elementwise(a, b, a.Field.Add)
+}
+
+// Sub subtracts two matricies
+func (d *Dense[T]) Sub(a, b *Dense[T]) *Dense[T] {
+	return d.elementwise(a, b, func(x, y T) T {
+		return a.Field.Add(x, a.Field.Neg(y))
+	})
+}
+
+// Neg negates a matrix
+func (d *Dense[T]) Neg(a *Dense[T]) *Dense[T] {
+	return d.elementwise(a, a, func(x, _ T) T {
+		return a.Field.Neg(x)
+	})
+}
+
+// Mul multiplies two matricies
+func (d *Dense[T]) Mul(a, b *Dense[T]) *Dense[T] {
+	if a.singular() || b.singular() {
+		return d.elementwise(a, b, a.Field.Mul)
+	}
+
+	field, values := a.Field, [][]T{}
+	for x := 0; x < len(a.Values); x++ {
+		var row []T
+		for y := 0; y < len(b.Values[0]); y++ {
+			sum := field.Zero()
+			for z := 0; z < len(b.Values); z++ {
+				sum = field.Add(sum, field.Mul(a.Values[x][z], b.Values[z][y]))
+			}
+			row = append(row, sum)
+		}
+		values = append(values, row)
+	}
+	d.Field, d.Values = field, values
+	return d
+}
+
+// Transpose transposes a matrix
+func (d *Dense[T]) Transpose(a *Dense[T]) *Dense[T] {
+	values := [][]T{}
+	if len(a.Values) > 0 {
+		for j := range a.Values[0] {
+			var row []T
+			for i := range a.Values {
+				row = append(row, a.set(a.Values[i][j]))
+			}
+			values = append(values, row)
+		}
+	}
+	d.Field, d.Values = a.Field, values
+	return d
+}
+
+// eliminate reduces the square matrix a to the identity with Gauss-Jordan elimination,
+// applying the same row operations to the rows of b, and returns the determinant of a.
+// a and b are changed, the elimination stops with a zero determinant if a is singular
+// https://en.wikipedia.org/wiki/Gaussian_elimination
+func eliminate[T any](field Field[T], a, b [][]T) T {
+	normed, isNormed := field.(Normed[T])
+	det := field.One()
+	for i := range a {
+		pivot := -1
+		var max *big.Float
+		for j := i; j < len(a); j++ {
+			if field.IsZero(a[j][i]) {
+				continue
+			}
+			if !isNormed {
+				pivot = j
+				break
+			}
+			if m := normed.Magnitude(a[j][i]); max == nil || m.Cmp(max) > 0 {
+				pivot, max = j, m
+			}
+		}
+		if pivot < 0 {
+			return field.Zero()
+		}
+		if pivot != i {
+			a[i], a[pivot] = a[pivot], a[i]
+			if b != nil {
+				b[i], b[pivot] = b[pivot], b[i]
+			}
+			det = field.Neg(det)
+		}
+
+		det = field.Mul(det, a[i][i])
+		inv := field.Inv(a[i][i])
+		scale := func(row []T) {
+			for k := range row {
+				row[k] = field.Mul(row[k], inv)
+			}
+		}
+		scale(a[i])
+		if b != nil {
+			scale(b[i])
+		}
+		for j := range a {
+			if j == i || field.IsZero(a[j][i]) {
+				continue
+			}
+			factor := field.Neg(a[j][i])
+			subtract := func(x, y []T) {
+				for k := range x {
+					x[k] = field.Add(x[k], field.Mul(factor, y[k]))
+				}
+			}
+			subtract(a[j], a[i])
+			if b != nil {
+				subtract(b[j], b[i])
+			}
+		}
+	}
+	return det
+}
+
+// copy copies the entries of d
+func (d *Dense[T]) copy() [][]T {
+	values := [][]T{}
+	for _, a := range d.Values {
+		var row []T
+		for i := range a {
+			row = append(row, d.set(a[i]))
+		}
+		values = append(values, row)
+	}
+	return values
+}
+
+// Det computes the determinant of a square matrix
+// https://en.wikipedia.org/wiki/Determinant
+func (d *Dense[T]) Det() T {
+	return eliminate(d.Field, d.copy(), nil)
+}
+
+// Inv computes the inverse of a square matrix, it panics if a is singular
+// https://en.wikipedia.org/wiki/Invertible_matrix
+func (d *Dense[T]) Inv(a *Dense[T]) *Dense[T] {
+	identity := Identity(a.Field, len(a.Values))
+	return d.Solve(a, &identity)
+}
+
+// Solve computes the solution x of a x = b for a square matrix a, it panics if a is singular
+// https://en.wikipedia.org/wiki/System_of_linear_equations
+func (d *Dense[T]) Solve(a, b *Dense[T]) *Dense[T] {
+	x := b.copy()
+	if a.Field.IsZero(eliminate(a.Field, a.copy(), x)) {
+		panic("singular matrix")
+	}
+	d.Field, d.Values = a.Field, x
+	return d
+}
+
+// String returns a string representation of the matrix
+func (d *Dense[T]) String() string {
+	s := "["
+	for i, row := range d.Values {
+		if i > 0 {
+			s += ";"
+		}
+		for j := range row {
+			if j > 0 {
+				s += " "
+			}
+			s += fmt.Sprint(row[j])
+		}
+	}
+	return s + "]"
+}
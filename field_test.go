@@ -0,0 +1,133 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// denseRationals creates a dense matrix of Rational numbers from pairs of fractions
+func denseRationals(values ...[]*big.Rat) Dense[*Rational] {
+	d := Dense[*Rational]{Field: RationalField{}}
+	for _, v := range values {
+		var row []*Rational
+		for i := 0; i < len(v); i += 2 {
+			row = append(row, NewRational(v[i], v[i+1]))
+		}
+		d.Values = append(d.Values, row)
+	}
+	return d
+}
+
+func TestDense_Rational(t *testing.T) {
+	r := big.NewRat
+	a := denseRationals(
+		[]*big.Rat{r(2, 1), r(0, 1), r(1, 1), r(1, 1), r(0, 1), r(0, 1)},
+		[]*big.Rat{r(1, 3), r(0, 1), r(0, 1), r(0, 1), r(4, 1), r(-1, 1)},
+		[]*big.Rat{r(0, 1), r(1, 1), r(5, 1), r(0, 1), r(1, 1), r(0, 1)},
+	)
+	if s := a.Det(); s.String() != "-130/3 + 44/3i" {
+		t.Fatal("invalid determinant", s.String())
+	}
+
+	inv := Dense[*Rational]{}
+	inv.Inv(&a)
+	product := Dense[*Rational]{}
+	product.Mul(&a, &inv)
+	identity := Identity[*Rational](RationalField{}, 3)
+	if product.String() != identity.String() {
+		t.Fatal("invalid inverse", product.String())
+	}
+	if product.String() != "[1/1 + 0/1i 0/1 + 0/1i 0/1 + 0/1i;0/1 + 0/1i 1/1 + 0/1i 0/1 + 0/1i;0/1 + 0/1i 0/1 + 0/1i 1/1 + 0/1i]" {
+		t.Fatal("invalid identity", product.String())
+	}
+
+	m := NewMatrix(64)
+	m.SetDense(&a)
+	if d := m.Dense(); d.String() != a.String() {
+		t.Fatal("invalid conversion", d.String())
+	}
+
+	// the Matrix functions are computed by the Dense functions
+	if s := m.Det(); s.String() != "-130/3 + 44/3i" {
+		t.Fatal("invalid determinant", s.String())
+	}
+	n := NewMatrix(64)
+	n.Inv(&m)
+	n.Mul(&m, &n)
+	if d := n.Dense(); d.String() != identity.String() {
+		t.Fatal("invalid inverse", d.String())
+	}
+	b := rationalMatrix(64, 1, []int64{1, 0}, []int64{0, 1}, []int64{2, -1})
+	x := NewMatrix(64)
+	x.Solve(&m, &b)
+	x.Mul(&m, &x)
+	if x.String() != b.String() {
+		t.Fatal("invalid solution", x.String())
+	}
+}
+
+func TestDense_Float(t *testing.T) {
+	field := NewFloatField(64, big.ToNearestEven)
+	a := NewDense[*Float](field, 2, 2)
+	a.Values[0][0] = field.Context.NewFloat(big.NewFloat(1e-30), nil)
+	a.Values[0][1] = field.Context.NewFloat(big.NewFloat(1), nil)
+	a.Values[1][0] = field.Context.NewFloat(big.NewFloat(1), nil)
+	a.Values[1][1] = field.Context.NewFloat(big.NewFloat(1), big.NewFloat(1))
+	b := NewDense[*Float](field, 2, 1)
+	b.Values[0][0] = field.One()
+	b.Values[1][0] = field.Context.NewFloat(big.NewFloat(2), nil)
+
+	// partial pivoting is needed for the tiny leading entry
+	x := Dense[*Float]{}
+	x.Solve(&a, &b)
+	t.Log(x.String())
+	if x.String() != "[1 + -1i;1 + 1e-30i]" {
+		t.Fatal("invalid solution", x.String())
+	}
+	y := Dense[*Float]{}
+	y.Mul(&a, &x)
+	y.Sub(&y, &b)
+	for _, row := range y.Values {
+		if m := field.Magnitude(row[0]); m.Sign() != 0 && m.MantExp(nil) > -60 {
+			t.Fatal("invalid residual", y.String())
+		}
+	}
+
+	if neg := field.Neg(field.One()); !neg.B.Signbit() || !neg.A.Signbit() {
+		t.Fatal("invalid negation", neg.String())
+	}
+}
+
+func TestDense_Modular(t *testing.T) {
+	field := NewModularField(big.NewInt(7))
+	a := NewDense[*big.Int](field, 2, 2)
+	a.Values = [][]*big.Int{{big.NewInt(3), big.NewInt(5)}, {big.NewInt(2), big.NewInt(6)}}
+	if det := a.Det(); det.Int64() != 1 {
+		t.Fatal("invalid determinant", det)
+	}
+	inv := Dense[*big.Int]{}
+	inv.Inv(&a)
+	if inv.String() != "[6 2;5 3]" {
+		t.Fatal("invalid inverse", inv.String())
+	}
+	inv.Mul(&inv, &a)
+	if inv.String() != "[1 0;0 1]" {
+		t.Fatal("invalid product", inv.String())
+	}
+
+	singular := NewDense[*big.Int](field, 2, 2)
+	singular.Values = [][]*big.Int{{big.NewInt(1), big.NewInt(2)}, {big.NewInt(3), big.NewInt(6)}}
+	if det := singular.Det(); det.Sign() != 0 {
+		t.Fatal("invalid determinant", det)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("singular matrix inverted")
+		}
+	}()
+	inv.Inv(&singular)
+}
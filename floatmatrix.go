@@ -0,0 +1,205 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// FloatMatrix is a matrix of Float numbers rounded to Prec and Mode, unlike Matrix the entries
+// aren't converted to exact rationals between functions. The receiver of a method may alias
+// the operands, which are never changed
+type FloatMatrix struct {
+	Prec   uint
+	Mode   big.RoundingMode
+	Values [][]Float
+}
+
+// NewFloatMatrix make a new float matrix
+func NewFloatMatrix(prec uint) FloatMatrix {
+	return FloatMatrix{
+		Prec: prec,
+	}
+}
+
+// SetMatrix sets m to the entries of a rounded to the precision of m
+func (m *FloatMatrix) SetMatrix(a *Matrix) *FloatMatrix {
+	values := [][]Float{}
+	for _, a := range a.Values {
+		var row []Float
+		for i := range a {
+			x := m.Context().NewFloat(nil, nil)
+			x.SetRat(&a[i])
+			row = append(row, *x)
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// SetFloatMatrix sets m to the exact values of the entries of a, it panics if an entry is
+// infinite or NaN, which has no rational value
+func (m *Matrix) SetFloatMatrix(a *FloatMatrix) *Matrix {
+	values := [][]Rational{}
+	for _, a := range a.Values {
+		var row []Rational
+		for i := range a {
+			if a[i].IsInf() || a[i].IsNaN() {
+				panic("non finite entry")
+			}
+			r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+			a[i].Rat(r)
+			row = append(row, *r)
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// dense views the entries of a as a dense matrix over the Float numbers rounded to the context of m
+func (m *FloatMatrix) dense(a *FloatMatrix) Dense[*Float] {
+	d := Dense[*Float]{
+		Field: FloatField{Context: m.Context()},
+	}
+	for i := range a.Values {
+		row := make([]*Float, len(a.Values[i]))
+		for j := range row {
+			row[j] = &a.Values[i][j]
+		}
+		d.Values = append(d.Values, row)
+	}
+	return d
+}
+
+// setDense sets m to the entries of a
+func (m *FloatMatrix) setDense(a *Dense[*Float]) *FloatMatrix {
+	values := [][]Float{}
+	for _, a := range a.Values {
+		var row []Float
+		for i := range a {
+			row = append(row, *a[i])
+		}
+		values = append(values, row)
+	}
+	m.Values = values
+	return m
+}
+
+// Add adds two float matricies
+func (m *FloatMatrix) Add(a, b *FloatMatrix) *FloatMatrix {
+	x, y := m.dense(a), m.dense(b)
+	return m.setDense(x.Add(&x, &y))
+}
+
+// Sub subtracts two float matricies
+func (m *FloatMatrix) Sub(a, b *FloatMatrix) *FloatMatrix {
+	x, y := m.dense(a), m.dense(b)
+	return m.setDense(x.Sub(&x, &y))
+}
+
+// Mul multiplies two float matricies
+func (m *FloatMatrix) Mul(a, b *FloatMatrix) *FloatMatrix {
+	x, y := m.dense(a), m.dense(b)
+	return m.setDense(x.Mul(&x, &y))
+}
+
+// Div divides two float matricies
+func (m *FloatMatrix) Div(a, b *FloatMatrix) *FloatMatrix {
+	x, y := m.dense(a), m.dense(b)
+	if !x.singular() || !y.singular() {
+		panic("can't divide non 1x1 matrices")
+	}
+
+	field := x.Field.(FloatField)
+	return m.setDense(x.elementwise(&x, &y, func(x, y *Float) *Float {
+		return field.Zero().Div(x, y)
+	}))
+}
+
+// apply applies the function to the entries of a
+func (m *FloatMatrix) apply(a *FloatMatrix, function func(f, x *Float) *Float) *FloatMatrix {
+	x := m.dense(a)
+	field := x.Field.(FloatField)
+	return m.setDense(x.elementwise(&x, &x, func(x, _ *Float) *Float {
+		return function(field.Zero(), x)
+	}))
+}
+
+// Abs computes the absolute value of the entries of the matrix
+func (m *FloatMatrix) Abs(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Abs)
+}
+
+// Conj computes the complex conjugate of a
+func (m *FloatMatrix) Conj(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Conj)
+}
+
+// Sqrt computes the square root of the matrix
+func (m *FloatMatrix) Sqrt(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Sqrt)
+}
+
+// Atan2 computes atan2 of x
+// https://en.wikipedia.org/wiki/Atan2
+func (m *FloatMatrix) Atan2(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Atan2)
+}
+
+// Arg computes arg(x + yi) = tan-1(y/x)
+// https://mathworld.wolfram.com/ComplexArgument.html
+func (m *FloatMatrix) Arg(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Arg)
+}
+
+// Exp computes e^x for the entries of the matrix
+func (m *FloatMatrix) Exp(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Exp)
+}
+
+// Cos computes the cosine of the entries of the matrix
+func (m *FloatMatrix) Cos(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Cos)
+}
+
+// Sin computes the sine of the entries of the matrix
+func (m *FloatMatrix) Sin(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Sin)
+}
+
+// Tan computes the tangent of the entries of the matrix
+func (m *FloatMatrix) Tan(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Tan)
+}
+
+// Log computes the natural log of the entries of the matrix
+func (m *FloatMatrix) Log(a *FloatMatrix) *FloatMatrix {
+	return m.apply(a, (*Float).Log)
+}
+
+// Pow computes x**y for the entries of the matrix
+// https://mathworld.wolfram.com/ComplexExponentiation.html
+func (m *FloatMatrix) Pow(x *FloatMatrix, y *Float) *FloatMatrix {
+	return m.apply(x, func(f, x *Float) *Float {
+		return f.Pow(x, y)
+	})
+}
+
+// Neg negates the matrix
+func (m *FloatMatrix) Neg(a *FloatMatrix) *FloatMatrix {
+	x := m.dense(a)
+	return m.setDense(x.Neg(&x))
+}
+
+// String returns a string representation of the matrix
+func (m *FloatMatrix) String() string {
+	d := m.dense(m)
+	if d.singular() {
+		return d.Values[0][0].String()
+	}
+	return d.String()
+}
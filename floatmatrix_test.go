@@ -0,0 +1,123 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// floatMatrixTest is the matrix of the float matrix tests
+func floatMatrixTest() Matrix {
+	a := NewMatrix(64)
+	a.Values = [][]Rational{
+		{*NewRational(big.NewRat(1, 3), big.NewRat(0, 1)), *NewRational(big.NewRat(2, 1), big.NewRat(1, 7))},
+		{*NewRational(big.NewRat(0, 1), big.NewRat(-1, 1)), *NewRational(big.NewRat(5, 11), big.NewRat(1, 1))},
+	}
+	return a
+}
+
+func TestFloatMatrix_SetMatrix(t *testing.T) {
+	a := floatMatrixTest()
+	f := NewFloatMatrix(64)
+	f.SetMatrix(&a)
+	if f.String() != a.String() {
+		t.Fatal("invalid conversion", f.String(), a.String())
+	}
+	b := NewMatrix(64)
+	b.SetFloatMatrix(&f)
+	g := NewFloatMatrix(64)
+	g.SetMatrix(&b)
+	if g.String() != f.String() {
+		t.Fatal("invalid conversion", g.String(), f.String())
+	}
+
+	// the entries are negated by the Float negation, which negates the signs of zero parts
+	g.Neg(&f)
+	c := NewMatrix(64)
+	c.SetFloatMatrix(&g)
+	if b.Neg(&a); c.String() != b.String() || !g.Values[1][0].A.Signbit() {
+		t.Fatal("invalid negation", g.String(), b.String())
+	}
+
+	f.Values[0][0].SetInf()
+	g.Neg(&f)
+	if !g.Values[0][0].IsInf() {
+		t.Fatal("invalid negation", g.String())
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("infinite entry converted")
+		}
+	}()
+	b.SetFloatMatrix(&f)
+}
+
+func TestFloatMatrix(t *testing.T) {
+	a := floatMatrixTest()
+	f := NewFloatMatrix(64)
+	f.SetMatrix(&a)
+
+	m := NewMatrix(64)
+	m.Mul(&a, &a)
+	m.Sub(&m, &a)
+	g := NewFloatMatrix(64)
+	g.Mul(&f, &f)
+	g.Sub(&g, &f)
+	t.Log(g.String())
+	if g.String() != m.String() {
+		t.Fatal("invalid result", g.String(), m.String())
+	}
+
+	functions := map[string]struct {
+		matrix func(m, a *Matrix) *Matrix
+		float  func(m, a *FloatMatrix) *FloatMatrix
+	}{
+		"Exp":  {(*Matrix).Exp, (*FloatMatrix).Exp},
+		"Log":  {(*Matrix).Log, (*FloatMatrix).Log},
+		"Sin":  {(*Matrix).Sin, (*FloatMatrix).Sin},
+		"Cos":  {(*Matrix).Cos, (*FloatMatrix).Cos},
+		"Tan":  {(*Matrix).Tan, (*FloatMatrix).Tan},
+		"Sqrt": {(*Matrix).Sqrt, (*FloatMatrix).Sqrt},
+		"Abs":  {(*Matrix).Abs, (*FloatMatrix).Abs},
+		"Conj": {(*Matrix).Conj, (*FloatMatrix).Conj},
+	}
+	for name, function := range functions {
+		m, g := NewMatrix(64), NewFloatMatrix(64)
+		function.matrix(&m, &a)
+		function.float(&g, &f)
+		if g.String() != m.String() {
+			t.Fatal("invalid result", name, g.String(), m.String())
+		}
+	}
+}
+
+func TestFloatMatrix_Chain(t *testing.T) {
+	// the entries keep the precision of the matrix
+	a := floatMatrixTest()
+	f := NewFloatMatrix(128)
+	f.SetMatrix(&a)
+	for i := 0; i < 32; i++ {
+		f.Log(&f)
+		f.Exp(&f)
+	}
+	for _, row := range f.Values {
+		for _, x := range row {
+			if x.A.Prec() != 128 || x.B.Prec() != 128 {
+				t.Fatal("invalid precision", x.A.Prec(), x.B.Prec())
+			}
+		}
+	}
+	g := NewFloatMatrix(128)
+	g.SetMatrix(&a)
+	g.Sub(&g, &f)
+	for _, row := range g.Values {
+		for _, x := range row {
+			if m := x.Modulus(); m.Sign() != 0 && m.MantExp(nil) > -120 {
+				t.Fatal("invalid result", g.String())
+			}
+		}
+	}
+}
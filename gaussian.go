@@ -0,0 +1,307 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"sort"
+)
+
+// GaussianInt is a Gaussian integer a + bi of the Euclidean domain Z[i], the receiver of a
+// method may alias the operands, which are never changed
+// https://en.wikipedia.org/wiki/Gaussian_integer
+type GaussianInt struct {
+	A, B *big.Int
+}
+
+// NewGaussianInt creates a new Gaussian integer
+func NewGaussianInt(a, b *big.Int) *GaussianInt {
+	return &GaussianInt{
+		A: a,
+		B: b,
+	}
+}
+
+// newGaussianInt creates a new Gaussian integer from int64 parts
+func newGaussianInt(a, b int64) *GaussianInt {
+	return NewGaussianInt(big.NewInt(a), big.NewInt(b))
+}
+
+// copy copies g into a new Gaussian integer
+func (g *GaussianInt) copy() *GaussianInt {
+	return NewGaussianInt(big.NewInt(0).Set(g.A), big.NewInt(0).Set(g.B))
+}
+
+// Set sets g to a
+func (g *GaussianInt) Set(a *GaussianInt) *GaussianInt {
+	g.A.Set(a.A)
+	g.B.Set(a.B)
+	return g
+}
+
+// Add adds two Gaussian integers
+func (g *GaussianInt) Add(a, b *GaussianInt) *GaussianInt {
+	g.A.Add(a.A, b.A)
+	g.B.Add(a.B, b.B)
+	return g
+}
+
+// Sub subtracts two Gaussian integers
+func (g *GaussianInt) Sub(a, b *GaussianInt) *GaussianInt {
+	g.A.Sub(a.A, b.A)
+	g.B.Sub(a.B, b.B)
+	return g
+}
+
+// Mul multiplies two Gaussian integers
+func (g *GaussianInt) Mul(a, b *GaussianInt) *GaussianInt {
+	x1, x2, x3, x4 :=
+		big.NewInt(0), big.NewInt(0),
+		big.NewInt(0), big.NewInt(0)
+	x1.Mul(a.A, b.A)
+	x2.Mul(a.A, b.B)
+	x3.Mul(a.B, b.A)
+	x4.Mul(a.B, b.B)
+	g.A.Sub(x1, x4)
+	g.B.Add(x2, x3)
+	return g
+}
+
+// Neg negates a Gaussian integer
+func (g *GaussianInt) Neg(a *GaussianInt) *GaussianInt {
+	g.A.Neg(a.A)
+	g.B.Neg(a.B)
+	return g
+}
+
+// Conj computes the complex conjugate of a
+func (g *GaussianInt) Conj(a *GaussianInt) *GaussianInt {
+	g.A.Set(a.A)
+	g.B.Neg(a.B)
+	return g
+}
+
+// Norm computes the norm a^2 + b^2 of g
+// https://en.wikipedia.org/wiki/Gaussian_integer#Norm
+func (g *GaussianInt) Norm() *big.Int {
+	x, y := big.NewInt(0).Mul(g.A, g.A), big.NewInt(0).Mul(g.B, g.B)
+	return x.Add(x, y)
+}
+
+// IsZero determines if g is 0
+func (g *GaussianInt) IsZero() bool {
+	return g.A.Sign() == 0 && g.B.Sign() == 0
+}
+
+// roundQuo computes x/y rounded to the nearest integer, with ties rounded up
+func roundQuo(x, y *big.Int) *big.Int {
+	// floor((2x + y)/2y) for y > 0
+	n := big.NewInt(0).Lsh(x, 1)
+	n.Add(n, y)
+	d := big.NewInt(0).Lsh(y, 1)
+	q, m := big.NewInt(0), big.NewInt(0)
+	q.DivMod(n, d, m)
+	return q
+}
+
+// QuoRem sets g to the quotient a/b rounded to the nearest Gaussian integer and returns the
+// quotient and the remainder r = a - b(a/b), which has N(r) <= N(b)/2. QuoRem panics if b is 0
+// https://en.wikipedia.org/wiki/Gaussian_integer#Euclidean_division
+func (g *GaussianInt) QuoRem(a, b *GaussianInt) (*GaussianInt, *GaussianInt) {
+	if b.IsZero() {
+		panic("division by zero")
+	}
+	n := b.Norm()
+	c := newGaussianInt(0, 0).Conj(b)
+	c.Mul(a, c)
+	q := NewGaussianInt(roundQuo(c.A, n), roundQuo(c.B, n))
+	r := newGaussianInt(0, 0).Mul(b, q)
+	r.Sub(a, r)
+	return g.Set(q), r
+}
+
+// divides determines if b divides a
+func divides(b, a *GaussianInt) bool {
+	_, r := newGaussianInt(0, 0).QuoRem(a, b)
+	return r.IsZero()
+}
+
+// normalize sets g to the associate of a in the first quadrant, with a > 0 and b >= 0
+// for a non zero a, and returns the unit u with a = u g
+func (g *GaussianInt) normalize(a *GaussianInt) (unit *GaussianInt) {
+	x := a.copy()
+	unit = newGaussianInt(1, 0)
+	i := newGaussianInt(0, 1)
+	for k := 0; k < 4 && !x.IsZero() && !(x.A.Sign() > 0 && x.B.Sign() >= 0); k++ {
+		// x = -i (i x)
+		x.Mul(x, i)
+		unit.Mul(unit, newGaussianInt(0, -1))
+	}
+	g.Set(x)
+	return unit
+}
+
+// GCD sets g to the greatest common divisor of a and b in the first quadrant
+// https://en.wikipedia.org/wiki/Gaussian_integer#Greatest_common_divisor
+func (g *GaussianInt) GCD(a, b *GaussianInt) *GaussianInt {
+	x, y := a.copy(), b.copy()
+	for !y.IsZero() {
+		_, r := newGaussianInt(0, 0).QuoRem(x, y)
+		x, y = y, r
+	}
+	g.normalize(x)
+	return g
+}
+
+// ExtendedGCD sets g to the greatest common divisor of a and b in the first quadrant and
+// returns x and y with g = ax + by
+// https://en.wikipedia.org/wiki/Extended_Euclidean_algorithm
+func (g *GaussianInt) ExtendedGCD(a, b *GaussianInt) (x, y *GaussianInt) {
+	r0, r1 := a.copy(), b.copy()
+	s0, s1 := newGaussianInt(1, 0), newGaussianInt(0, 0)
+	t0, t1 := newGaussianInt(0, 0), newGaussianInt(1, 0)
+	next := func(u0, u1, q *GaussianInt) *GaussianInt {
+		u := newGaussianInt(0, 0).Mul(q, u1)
+		return u.Sub(u0, u)
+	}
+	for !r1.IsZero() {
+		q, r := newGaussianInt(0, 0).QuoRem(r0, r1)
+		r0, r1 = r1, r
+		s0, s1 = s1, next(s0, s1, q)
+		t0, t1 = t1, next(t0, t1, q)
+	}
+	// r0 = u g for the unit u, so g = r0 conj(u)
+	unit := g.normalize(r0)
+	unit.Conj(unit)
+	return s0.Mul(s0, unit), t0.Mul(t0, unit)
+}
+
+// ProbablyPrime determines if g is a Gaussian prime, with the probability of a false positive
+// given by big.Int.ProbablyPrime(n): a + bi is prime if a^2 + b^2 is prime, or if one part is 0
+// and the absolute value of the other part is a prime congruent to 3 mod 4
+// https://en.wikipedia.org/wiki/Gaussian_integer#Gaussian_primes
+func (g *GaussianInt) ProbablyPrime(n int) bool {
+	if g.A.Sign() == 0 || g.B.Sign() == 0 {
+		p := big.NewInt(0).Abs(g.A)
+		if g.A.Sign() == 0 {
+			p.Abs(g.B)
+		}
+		return p.Bit(0) == 1 && p.Bit(1) == 1 && p.ProbablyPrime(n)
+	}
+	return g.Norm().ProbablyPrime(n)
+}
+
+// pollard finds a non trivial factor of the composite n with Pollard's rho algorithm
+// https://en.wikipedia.org/wiki/Pollard%27s_rho_algorithm
+func pollard(n *big.Int) *big.Int {
+	if n.Bit(0) == 0 {
+		return big.NewInt(2)
+	}
+	for c := int64(1); ; c++ {
+		f := func(x *big.Int) *big.Int {
+			x.Mul(x, x)
+			x.Add(x, big.NewInt(c))
+			return x.Mod(x, n)
+		}
+		x, y, d := big.NewInt(2), big.NewInt(2), big.NewInt(1)
+		for d.Cmp(big.NewInt(1)) == 0 {
+			f(x)
+			f(f(y))
+			d.Sub(x, y)
+			d.Abs(d)
+			d.GCD(nil, nil, d, n)
+		}
+		if d.Cmp(n) != 0 {
+			return d
+		}
+	}
+}
+
+// factorInt computes the prime factors of n > 0 in increasing order with multiplicity
+func factorInt(n *big.Int) []*big.Int {
+	var factors []*big.Int
+	n = big.NewInt(0).Set(n)
+	for p := int64(2); p < 1024 && n.Cmp(big.NewInt(1)) > 0; p++ {
+		d, m := big.NewInt(0), big.NewInt(0)
+		for {
+			d.DivMod(n, big.NewInt(p), m)
+			if m.Sign() != 0 {
+				break
+			}
+			factors = append(factors, big.NewInt(p))
+			n.Set(d)
+		}
+	}
+	var split func(n *big.Int)
+	split = func(n *big.Int) {
+		if n.Cmp(big.NewInt(1)) == 0 {
+			return
+		}
+		if n.ProbablyPrime(32) {
+			factors = append(factors, n)
+			return
+		}
+		d := pollard(n)
+		split(d)
+		split(big.NewInt(0).Quo(n, d))
+	}
+	split(n)
+	sort.Slice(factors, func(i, j int) bool {
+		return factors[i].Cmp(factors[j]) < 0
+	})
+	return factors
+}
+
+// Factor factors g into a unit and Gaussian primes in the first quadrant, ordered by norm
+// and then by the parts, so that g is the product of the unit and the primes. Factor panics if g is 0
+// https://en.wikipedia.org/wiki/Gaussian_integer#Unique_factorization
+func (g *GaussianInt) Factor() (unit *GaussianInt, primes []*GaussianInt) {
+	if g.IsZero() {
+		panic("factorization of zero")
+	}
+	rest := g.copy()
+	divide := func(p *GaussianInt) {
+		for divides(p, rest) {
+			rest.QuoRem(rest, p)
+			primes = append(primes, p.copy())
+		}
+	}
+	var last *big.Int
+	for _, p := range factorInt(g.Norm()) {
+		if last != nil && last.Cmp(p) == 0 {
+			continue
+		}
+		last = p
+		switch m := p.Bit(0) + 2*p.Bit(1); {
+		case p.Cmp(big.NewInt(2)) == 0:
+			divide(newGaussianInt(1, 1))
+		case m == 3:
+			divide(NewGaussianInt(big.NewInt(0).Set(p), big.NewInt(0)))
+		default:
+			// p = pi conj(pi) with pi = gcd(p, k + i) for k^2 = -1 mod p
+			k := big.NewInt(0).ModSqrt(big.NewInt(0).Sub(p, big.NewInt(1)), p)
+			pi := newGaussianInt(0, 0).GCD(NewGaussianInt(p, big.NewInt(0)), NewGaussianInt(k, big.NewInt(1)))
+			conj := newGaussianInt(0, 0).Conj(pi)
+			conj.normalize(conj)
+			divide(pi)
+			divide(conj)
+		}
+	}
+	sort.Slice(primes, func(i, j int) bool {
+		if c := primes[i].Norm().Cmp(primes[j].Norm()); c != 0 {
+			return c < 0
+		}
+		if c := primes[i].A.Cmp(primes[j].A); c != 0 {
+			return c < 0
+		}
+		return primes[i].B.Cmp(primes[j].B) < 0
+	})
+	return rest, primes
+}
+
+// String returns a string of the Gaussian integer
+func (g *GaussianInt) String() string {
+	return g.A.String() + " + " + g.B.String() + "i"
+}
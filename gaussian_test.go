@@ -0,0 +1,155 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+func TestGaussianInt_Arithmetic(t *testing.T) {
+	a, b := newGaussianInt(3, 4), newGaussianInt(-2, 5)
+	if s := newGaussianInt(0, 0).Add(a, b).String(); s != "1 + 9i" {
+		t.Fatal("invalid sum", s)
+	}
+	if s := newGaussianInt(0, 0).Sub(a, b).String(); s != "5 + -1i" {
+		t.Fatal("invalid difference", s)
+	}
+	if s := newGaussianInt(0, 0).Mul(a, b).String(); s != "-26 + 7i" {
+		t.Fatal("invalid product", s)
+	}
+	if n := a.Norm(); n.Int64() != 25 {
+		t.Fatal("invalid norm", n)
+	}
+}
+
+func TestGaussianInt_QuoRem(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	random := func() *GaussianInt {
+		return newGaussianInt(rng.Int63n(2001)-1000, rng.Int63n(2001)-1000)
+	}
+	for i := 0; i < 256; i++ {
+		a, b := random(), random()
+		if b.IsZero() {
+			continue
+		}
+		q, r := newGaussianInt(0, 0).QuoRem(a, b)
+		x := newGaussianInt(0, 0).Mul(b, q)
+		x.Add(x, r)
+		if x.String() != a.String() {
+			t.Fatal("invalid division", a, b, q, r)
+		}
+		n := big.NewInt(0).Lsh(r.Norm(), 1)
+		if n.Cmp(b.Norm()) > 0 {
+			t.Fatal("invalid remainder", a, b, r)
+		}
+	}
+}
+
+func TestGaussianInt_GCD(t *testing.T) {
+	a, b := newGaussianInt(11, 3), newGaussianInt(1, 8)
+	g := newGaussianInt(0, 0).GCD(a, b)
+	if g.String() != "2 + 1i" {
+		t.Fatal("invalid gcd", g)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 64; i++ {
+		c := newGaussianInt(rng.Int63n(201)-100, rng.Int63n(201)-100)
+		a := newGaussianInt(0, 0).Mul(c, newGaussianInt(rng.Int63n(201)-100, rng.Int63n(201)-100))
+		b := newGaussianInt(0, 0).Mul(c, newGaussianInt(rng.Int63n(201)-100, rng.Int63n(201)-100))
+		g := newGaussianInt(0, 0)
+		x, y := g.ExtendedGCD(a, b)
+		if g.String() != newGaussianInt(0, 0).GCD(a, b).String() {
+			t.Fatal("invalid gcd", a, b, g)
+		}
+		if !a.IsZero() || !b.IsZero() {
+			if !divides(g, a) || !divides(g, b) || !divides(c, g) || g.A.Sign() <= 0 || g.B.Sign() < 0 {
+				t.Fatal("invalid gcd", a, b, g)
+			}
+		}
+		s := newGaussianInt(0, 0).Mul(a, x)
+		s.Add(s, newGaussianInt(0, 0).Mul(b, y))
+		if s.String() != g.String() {
+			t.Fatal("invalid bezout coefficients", a, b, x, y, g)
+		}
+	}
+}
+
+func TestGaussianInt_ProbablyPrime(t *testing.T) {
+	primes := []*GaussianInt{newGaussianInt(1, 1), newGaussianInt(3, 0), newGaussianInt(0, -7),
+		newGaussianInt(2, 1), newGaussianInt(-4, 5)}
+	for _, p := range primes {
+		if !p.ProbablyPrime(20) {
+			t.Fatal("prime not detected", p)
+		}
+	}
+	composites := []*GaussianInt{newGaussianInt(2, 0), newGaussianInt(5, 0), newGaussianInt(0, 13),
+		newGaussianInt(3, 4), newGaussianInt(1, 0), newGaussianInt(0, 0)}
+	for _, c := range composites {
+		if c.ProbablyPrime(20) {
+			t.Fatal("composite detected as prime", c)
+		}
+	}
+}
+
+func TestGaussianInt_Factor(t *testing.T) {
+	unit, primes := newGaussianInt(-60, 80).Factor()
+	s := unit.String() + ":"
+	for _, p := range primes {
+		s += " " + p.String()
+	}
+	t.Log(s)
+	if s != "0 + 1i: 1 + 1i 1 + 1i 1 + 1i 1 + 1i 1 + 2i 1 + 2i 1 + 2i 2 + 1i" {
+		t.Fatal("invalid factorization", s)
+	}
+
+	// the inert prime 3 has the norm 9, which is larger than the norm 5 of the split primes
+	unit, primes = newGaussianInt(15, 0).Factor()
+	s = unit.String() + ":"
+	for _, p := range primes {
+		s += " " + p.String()
+	}
+	t.Log(s)
+	if s != "0 + -1i: 1 + 2i 2 + 1i 3 + 0i" {
+		t.Fatal("invalid factorization", s)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 32; i++ {
+		a := newGaussianInt(rng.Int63n(2000001)-1000000, rng.Int63n(2000001)-1000000)
+		if a.IsZero() {
+			continue
+		}
+		unit, primes := a.Factor()
+		if unit.Norm().Int64() != 1 {
+			t.Fatal("invalid unit", a, unit)
+		}
+		product := unit.copy()
+		for _, p := range primes {
+			if !p.ProbablyPrime(20) || p.A.Sign() <= 0 || p.B.Sign() < 0 {
+				t.Fatal("invalid prime", a, p)
+			}
+			product.Mul(product, p)
+		}
+		if product.String() != a.String() {
+			t.Fatal("invalid factorization", a, product)
+		}
+	}
+
+	// a product of large primes needs Pollard's rho
+	p, _ := big.NewInt(0).SetString("1000000007", 10)
+	q, _ := big.NewInt(0).SetString("998244353", 10)
+	a := NewGaussianInt(p, q)
+	unit, primes = a.Factor()
+	product := unit.copy()
+	for _, p := range primes {
+		product.Mul(product, p)
+	}
+	if product.String() != a.String() {
+		t.Fatal("invalid factorization", a, product)
+	}
+}
@@ -1,6 +1,6 @@
 module github.com/pointlander/c0mpl3x
 
-go 1.13
+go 1.18
 
 replace github.com/ALTree/bigfloat => github.com/pointlander/bigfloat v0.0.0-20201211040007-2034219fcdd4
 
@@ -0,0 +1,238 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// gaussianMatrix converts the entries of a to Gaussian integers and determines if the entries
+// are real, it panics if an entry of a isn't a Gaussian integer
+func gaussianMatrix(a *Matrix) (values [][]*GaussianInt, real bool) {
+	real = true
+	for _, row := range a.Values {
+		var r []*GaussianInt
+		for i := range row {
+			x := &row[i]
+			if !x.A.IsInt() || !x.B.IsInt() {
+				panic("non integer matrix")
+			}
+			real = real && x.B.Sign() == 0
+			r = append(r, NewGaussianInt(big.NewInt(0).Set(x.A.Num()), big.NewInt(0).Set(x.B.Num())))
+		}
+		values = append(values, r)
+	}
+	return values, real
+}
+
+// setGaussianMatrix sets m to the Gaussian integers
+func (m *Matrix) setGaussianMatrix(values [][]*GaussianInt) *Matrix {
+	rows := [][]Rational{}
+	for _, row := range values {
+		var r []Rational
+		for _, x := range row {
+			r = append(r, *NewRational(big.NewRat(0, 1).SetInt(x.A), big.NewRat(0, 1).SetInt(x.B)))
+		}
+		rows = append(rows, r)
+	}
+	m.Values = rows
+	return m
+}
+
+// gaussianIdentity creates an identity matrix of Gaussian integers
+func gaussianIdentity(n int) [][]*GaussianInt {
+	values := make([][]*GaussianInt, n)
+	for i := range values {
+		for j := 0; j < n; j++ {
+			values[i] = append(values[i], newGaussianInt(0, 0))
+		}
+		values[i][i].A.SetInt64(1)
+	}
+	return values
+}
+
+// unimodular is the 2x2 matrix [[x y] [z w]] with a unit determinant
+type unimodular struct {
+	x, y, z, w *GaussianInt
+}
+
+// eliminator computes the unimodular matrix that maps the column (a, b) to (gcd(a, b), 0)
+// with gcd(a, b) = xa + yb and [[x y] [-b/g a/g]], or [[1 0] [-b/a 1]] if a divides b so a
+// is kept, or the swap [[0 1] [1 0]] if a is zero
+func eliminator(a, b *GaussianInt) unimodular {
+	if a.IsZero() {
+		return unimodular{newGaussianInt(0, 0), newGaussianInt(1, 0), newGaussianInt(1, 0), newGaussianInt(0, 0)}
+	}
+	if q, r := newGaussianInt(0, 0).QuoRem(b, a); r.IsZero() {
+		return unimodular{newGaussianInt(1, 0), newGaussianInt(0, 0), q.Neg(q), newGaussianInt(1, 0)}
+	}
+	g := newGaussianInt(0, 0)
+	x, y := g.ExtendedGCD(a, b)
+	u, _ := newGaussianInt(0, 0).QuoRem(a, g)
+	v, _ := newGaussianInt(0, 0).QuoRem(b, g)
+	return unimodular{x, y, v.Neg(v), u}
+}
+
+// combine computes xs + yt
+func combine(x, s, y, t *GaussianInt) *GaussianInt {
+	a := newGaussianInt(0, 0).Mul(x, s)
+	return a.Add(a, newGaussianInt(0, 0).Mul(y, t))
+}
+
+// rows replaces the rows i and j of a with x r_i + y r_j and z r_i + w r_j
+func (u unimodular) rows(a [][]*GaussianInt, i, j int) {
+	for k := range a[i] {
+		s, t := a[i][k], a[j][k]
+		a[i][k], a[j][k] = combine(u.x, s, u.y, t), combine(u.z, s, u.w, t)
+	}
+}
+
+// columns replaces the columns i and j of a with x c_i + y c_j and z c_i + w c_j
+func (u unimodular) columns(a [][]*GaussianInt, i, j int) {
+	for k := range a {
+		s, t := a[k][i], a[k][j]
+		a[k][i], a[k][j] = combine(u.x, s, u.y, t), combine(u.z, s, u.w, t)
+	}
+}
+
+// reduce computes the quotient q of x by the pivot p for the remainder x - qp, which is in
+// [0, p) for real x and p and has the norm at most N(p)/2 otherwise
+func reduce(x, p *GaussianInt, real bool) *GaussianInt {
+	if real {
+		return NewGaussianInt(big.NewInt(0).Div(x.A, p.A), big.NewInt(0))
+	}
+	q, _ := newGaussianInt(0, 0).QuoRem(x, p)
+	return q
+}
+
+// normalizeRow multiplies the row i of a and of the transform by the inverse of the unit of
+// the entry in the column j, so the entry is in the first quadrant
+func normalizeRow(a, transform [][]*GaussianInt, i, j int) {
+	unit := newGaussianInt(0, 0).normalize(a[i][j])
+	// the inverse of a unit is its conjugate
+	unit.Conj(unit)
+	for _, m := range [][][]*GaussianInt{a, transform} {
+		for k := range m[i] {
+			m[i][k] = newGaussianInt(0, 0).Mul(m[i][k], unit)
+		}
+	}
+}
+
+// Hermite sets m to the Hermite normal form h of a matrix a of Gaussian integers and returns m
+// and the unimodular matrix u with h = ua. h is upper triangular, the pivots are in the first
+// quadrant and the entries above a pivot p are reduced modulo p, in [0, p) for an integer
+// matrix. Hermite panics if an entry of a isn't a Gaussian integer
+// https://en.wikipedia.org/wiki/Hermite_normal_form
+func (m *Matrix) Hermite(a *Matrix) (h *Matrix, u *Matrix) {
+	values, real := gaussianMatrix(a)
+	transform := gaussianIdentity(len(values))
+	row := 0
+	for column := 0; len(values) > 0 && column < len(values[0]) && row < len(values); column++ {
+		for j := row + 1; j < len(values); j++ {
+			if values[j][column].IsZero() {
+				continue
+			}
+			e := eliminator(values[row][column], values[j][column])
+			e.rows(values, row, j)
+			e.rows(transform, row, j)
+		}
+		if values[row][column].IsZero() {
+			continue
+		}
+		normalizeRow(values, transform, row, column)
+		for k := 0; k < row; k++ {
+			q := reduce(values[k][column], values[row][column], real)
+			e := unimodular{newGaussianInt(1, 0), q.Neg(q), newGaussianInt(0, 0), newGaussianInt(1, 0)}
+			e.rows(values, k, row)
+			e.rows(transform, k, row)
+		}
+		row++
+	}
+	u = &Matrix{Prec: m.Prec, Mode: m.Mode}
+	u.setGaussianMatrix(transform)
+	return m.setGaussianMatrix(values), u
+}
+
+// Smith sets m to the Smith normal form s of a matrix a of Gaussian integers and returns m and
+// the unimodular matrices u and v with s = uav. s is diagonal with the invariant factors
+// d1 | d2 | ... in the first quadrant, which are positive for an integer matrix. Smith panics if
+// an entry of a isn't a Gaussian integer
+// https://en.wikipedia.org/wiki/Smith_normal_form
+func (m *Matrix) Smith(a *Matrix) (s *Matrix, u *Matrix, v *Matrix) {
+	values, _ := gaussianMatrix(a)
+	rows, columns := len(values), 0
+	if rows > 0 {
+		columns = len(values[0])
+	}
+	left, right := gaussianIdentity(rows), gaussianIdentity(columns)
+	swap := unimodular{newGaussianInt(0, 0), newGaussianInt(1, 0), newGaussianInt(1, 0), newGaussianInt(0, 0)}
+	for t := 0; t < rows && t < columns; t++ {
+		// the entry with the least norm is the pivot
+		pi, pj := -1, -1
+		var least *big.Int
+		for i := t; i < rows; i++ {
+			for j := t; j < columns; j++ {
+				if n := values[i][j].Norm(); n.Sign() != 0 && (least == nil || n.Cmp(least) < 0) {
+					pi, pj, least = i, j, n
+				}
+			}
+		}
+		if pi < 0 {
+			break
+		}
+		if pi != t {
+			swap.rows(values, t, pi)
+			swap.rows(left, t, pi)
+		}
+		if pj != t {
+			swap.columns(values, t, pj)
+			swap.columns(right, t, pj)
+		}
+		for {
+			for i := t + 1; i < rows; i++ {
+				if !values[i][t].IsZero() {
+					e := eliminator(values[t][t], values[i][t])
+					e.rows(values, t, i)
+					e.rows(left, t, i)
+				}
+			}
+			for j := t + 1; j < columns; j++ {
+				if !values[t][j].IsZero() {
+					e := eliminator(values[t][t], values[t][j])
+					e.columns(values, t, j)
+					e.columns(right, t, j)
+				}
+			}
+			clear := true
+			for i := t + 1; i < rows; i++ {
+				clear = clear && values[i][t].IsZero()
+			}
+			if !clear {
+				continue
+			}
+			// the pivot divides the rest of the matrix, otherwise a row is added to the pivot row
+			divisible := true
+			for i := t + 1; i < rows && divisible; i++ {
+				for j := t + 1; j < columns; j++ {
+					if !divides(values[t][t], values[i][j]) {
+						e := unimodular{newGaussianInt(1, 0), newGaussianInt(1, 0), newGaussianInt(0, 0), newGaussianInt(1, 0)}
+						e.rows(values, t, i)
+						e.rows(left, t, i)
+						divisible = false
+						break
+					}
+				}
+			}
+			if divisible {
+				break
+			}
+		}
+		normalizeRow(values, left, t, t)
+	}
+	u, v = &Matrix{Prec: m.Prec, Mode: m.Mode}, &Matrix{Prec: m.Prec, Mode: m.Mode}
+	u.setGaussianMatrix(left)
+	v.setGaussianMatrix(right)
+	return m.setGaussianMatrix(values), u, v
+}
@@ -0,0 +1,96 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"testing"
+)
+
+// unit determines if the determinant of a is a unit of Z[i]
+func unit(a *Matrix) bool {
+	d := a.Dense()
+	det := d.Det()
+	s := det.String()
+	return s == "1/1 + 0/1i" || s == "-1/1 + 0/1i" || s == "0/1 + 1/1i" || s == "0/1 + -1/1i"
+}
+
+func TestMatrix_Hermite(t *testing.T) {
+	a := rationalMatrix(64, 1,
+		[]int64{3, 0, 3, 0, 1, 0, 4, 0},
+		[]int64{0, 0, 1, 0, 0, 0, 0, 0},
+		[]int64{0, 0, 0, 0, 19, 0, 16, 0},
+		[]int64{0, 0, 0, 0, 0, 0, 3, 0},
+	)
+	h := NewMatrix(64)
+	_, u := h.Hermite(&a)
+	if h.String() != "[3 0 1 1;0 1 0 0;0 0 19 1;0 0 0 3]" {
+		t.Fatal("invalid Hermite normal form", h.String())
+	}
+	product := NewMatrix(64)
+	product.Mul(u, &a)
+	if product.String() != h.String() || !unit(u) {
+		t.Fatal("invalid transformation", u.String())
+	}
+
+	b := rationalMatrix(64, 1,
+		[]int64{2, 1, 3, 0, 0, 1},
+		[]int64{1, -1, 4, 2, 5, 0},
+		[]int64{3, 0, 7, 2, 5, 1},
+	)
+	_, u = h.Hermite(&b)
+	product.Mul(u, &b)
+	if product.String() != h.String() || !unit(u) {
+		t.Fatal("invalid transformation", u.String())
+	}
+	if h.String() != "[1 -6 + -1i -4 + 5i;0 3 + 11i 9 + 4i;0 0 0]" {
+		t.Fatal("invalid Hermite normal form", h.String())
+	}
+
+	c := rationalMatrix(64, 1,
+		[]int64{0, 0, 1, 0},
+		[]int64{1, 0, 0, 0},
+	)
+	_, u = h.Hermite(&c)
+	product.Mul(u, &c)
+	if product.String() != h.String() || !unit(u) {
+		t.Fatal("invalid transformation", u.String())
+	}
+	if h.String() != "[1 0;0 1]" {
+		t.Fatal("invalid Hermite normal form", h.String())
+	}
+}
+
+func TestMatrix_Smith(t *testing.T) {
+	a := rationalMatrix(64, 1,
+		[]int64{2, 0, 4, 0, 4, 0},
+		[]int64{-6, 0, 6, 0, 12, 0},
+		[]int64{10, 0, -4, 0, -16, 0},
+	)
+	s := NewMatrix(64)
+	_, u, v := s.Smith(&a)
+	if s.String() != "[2 0 0;0 6 0;0 0 12]" {
+		t.Fatal("invalid Smith normal form", s.String())
+	}
+	product := NewMatrix(64)
+	product.Mul(u, &a)
+	product.Mul(&product, v)
+	if product.String() != s.String() || !unit(u) || !unit(v) {
+		t.Fatal("invalid transformation", u.String(), v.String())
+	}
+
+	b := rationalMatrix(64, 1,
+		[]int64{2, 1, 3, 0, 0, 1},
+		[]int64{1, -1, 4, 2, 5, 0},
+	)
+	_, u, v = s.Smith(&b)
+	product.Mul(u, &b)
+	product.Mul(&product, v)
+	if product.String() != s.String() || !unit(u) || !unit(v) {
+		t.Fatal("invalid transformation", u.String(), v.String())
+	}
+	if s.String() != "[1 0 0;0 1 0]" {
+		t.Fatal("invalid Smith normal form", s.String())
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// lambertGuard is the number of guard bits used by the Lambert W function
+const lambertGuard = 32
+
+// lambertGuess computes an initial approximation of W_k(z)
+// https://doi.org/10.1007/BF02124750
+func lambertGuess(k int, z *Float, prec uint) *Float {
+	one := big.NewFloat(1).SetPrec(prec)
+	e := bigfloat.Exp(big.NewFloat(1).SetPrec(prec))
+
+	// near the branch point -1/e with p = sqrt(2(ez + 1))
+	// w = -1 + p - p^2/3 + 11p^3/72
+	d := z.clone(prec)
+	d.A.Mul(d.A, e)
+	d.B.Mul(d.B, e)
+	d.A.Add(d.A, one)
+	if norm64(d) < .25 && (k == 0 || (k == -1 && z.B.Sign() >= 0) || (k == 1 && z.B.Sign() < 0)) {
+		d.A.Add(d.A, d.A)
+		d.B.Add(d.B, d.B)
+		p := sqrt(d, prec)
+		if k != 0 {
+			p.A.Neg(p.A)
+			p.B.Neg(p.B)
+		}
+		p2, p3 := newFloat(prec), newFloat(prec)
+		p2.Mul(p, p)
+		p3.Mul(p2, p)
+		w := p.clone(prec)
+		w.A.Sub(w.A, one)
+		third := big.NewFloat(3).SetPrec(prec)
+		p2.A.Quo(p2.A, third)
+		p2.B.Quo(p2.B, third)
+		w.Sub(w, p2)
+		c := big.NewFloat(11).SetPrec(prec)
+		c.Quo(c, big.NewFloat(72).SetPrec(prec))
+		p3.A.Mul(p3.A, c)
+		p3.B.Mul(p3.B, c)
+		return w.Add(w, p3)
+	}
+
+	// pade approximation around zero w = z(3 + 6z + z^2)/(3 + 9z + 5z^2)
+	a, _ := z.A.Float64()
+	b, _ := z.B.Float64()
+	if k == 0 && -1 < a && a < 1.5 && math.Abs(b) < 1 && -2.5*math.Abs(b)-.2 < a {
+		zz := newFloat(prec)
+		zz.Mul(z, z)
+		n := zz.clone(prec)
+		n.A.Add(n.A, big.NewFloat(3).SetPrec(prec))
+		t := z.clone(prec)
+		t.A.Mul(t.A, big.NewFloat(6).SetPrec(prec))
+		t.B.Mul(t.B, big.NewFloat(6).SetPrec(prec))
+		n.Add(n, t)
+		n.Mul(n, z)
+		m := zz.clone(prec)
+		m.A.Mul(m.A, big.NewFloat(5).SetPrec(prec))
+		m.B.Mul(m.B, big.NewFloat(5).SetPrec(prec))
+		m.A.Add(m.A, big.NewFloat(3).SetPrec(prec))
+		t = z.clone(prec)
+		t.A.Mul(t.A, big.NewFloat(9).SetPrec(prec))
+		t.B.Mul(t.B, big.NewFloat(9).SetPrec(prec))
+		m.Add(m, t)
+		m.inv(m)
+		return n.Mul(n, m)
+	}
+
+	// asymptotic expansion with L1 = log(z) + 2 pi i k and L2 = log(L1)
+	// w = L1 - L2 + L2/L1 + L2(L2 - 2)/(2 L1^2)
+	l1 := log(z, prec)
+	pi := bigfloat.PI(prec)
+	pi.Mul(pi, big.NewFloat(float64(2*k)).SetPrec(prec))
+	l1.B.Add(l1.B, pi)
+	l2 := log(l1, prec)
+	inv := newFloat(prec)
+	inv.inv(l1)
+	w := newFloat(prec)
+	w.Sub(l1, l2)
+	t := newFloat(prec)
+	t.Mul(l2, inv)
+	w.Add(w, t)
+	t.Mul(t, inv)
+	s := l2.clone(prec)
+	s.A.Sub(s.A, big.NewFloat(2).SetPrec(prec))
+	t.Mul(t, s)
+	t.A.Quo(t.A, big.NewFloat(2).SetPrec(prec))
+	t.B.Quo(t.B, big.NewFloat(2).SetPrec(prec))
+	return w.Add(w, t)
+}
+
+// lambertW computes W_k(z) with Halley's method
+// w = w - (we^w - z)/(e^w(w + 1) - (w + 2)(we^w - z)/(2w + 2))
+// https://en.wikipedia.org/wiki/Lambert_W_function#Numerical_evaluation
+func lambertW(k int, z *Float, prec uint) *Float {
+	z = z.clone(prec)
+	if z.A.Sign() == 0 && z.B.Sign() == 0 {
+		w := newFloat(prec)
+		if k != 0 {
+			w.A.SetInf(true)
+		}
+		return w
+	}
+
+	one := big.NewFloat(1).SetPrec(prec)
+	two := big.NewFloat(2).SetPrec(prec)
+	eps := big.NewFloat(0).SetPrec(prec).SetMantExp(big.NewFloat(1).SetPrec(prec), -2*int(prec-lambertGuard/2))
+	w := lambertGuess(k, z, prec)
+	ew, f, a, b, t := newFloat(prec), newFloat(prec), newFloat(prec), newFloat(prec), newFloat(prec)
+	for i := 0; i < 128; i++ {
+		ew = exp(w, prec)
+		f.Mul(w, ew)
+		f.Sub(f, z)
+		if f.A.Sign() == 0 && f.B.Sign() == 0 {
+			break
+		}
+		w1 := w.clone(prec)
+		w1.A.Add(w1.A, one)
+		if w1.A.Sign() == 0 && w1.B.Sign() == 0 {
+			break
+		}
+		a.Mul(ew, w1)
+		b.Add(w1, NewFloat(one, big.NewFloat(0).SetPrec(prec)))
+		b.Mul(b, f)
+		w1.A.Mul(w1.A, two)
+		w1.B.Mul(w1.B, two)
+		w1.inv(w1)
+		b.Mul(b, w1)
+		a.Sub(a, b)
+		a.inv(a)
+		t.Mul(f, a)
+		w.Sub(w, t)
+		if norm(t).Cmp(big.NewFloat(0).SetPrec(prec).Mul(eps, norm(w))) <= 0 {
+			break
+		}
+	}
+	return w
+}
+
+// lambertBranch computes d = ez + 1 with enough precision to find its exponent unless it is
+// smaller than 2^-2prec. The derivative of we^w vanishes at the branch point w = -1, so the
+// residual of Halley's method loses -log2|d|/2 bits near z = -1/e
+func lambertBranch(z *Float, prec uint) *Float {
+	wp := 2*prec + z.A.Prec() + z.B.Prec()
+	d := z.clone(wp)
+	e := bigfloat.Exp(big.NewFloat(1).SetPrec(wp))
+	d.A.Mul(d.A, e)
+	d.B.Mul(d.B, e)
+	d.A.Add(d.A, big.NewFloat(1).SetPrec(wp))
+	return d
+}
+
+// LambertW computes the branch k of the Lambert W function, the solution of w e^w = x
+// https://en.wikipedia.org/wiki/Lambert_W_function
+func (f *Float) LambertW(k int, x *Float) *Float {
+	return f.unary(x, infinite, func() *Float {
+		prec := f.A.Prec() + lambertGuard
+		d := lambertBranch(x, prec)
+		wp := prec
+		if n := norm(d); n.Sign() == 0 {
+			wp += prec
+		} else if e := n.MantExp(nil); e < 0 {
+			// |d| = sqrt(n) so -log2|d|/2 = -log2(n)/4
+			wp += uint(-e/4) + 1
+		}
+		w := lambertW(k, x, wp)
+
+		// W_0 is real on [-1/e, inf) and W_-1 is real on [-1/e, 0)
+		real := x.B.Sign() == 0 && d.A.Sign() >= 0 && (k == 0 || (k == -1 && x.A.Sign() < 0))
+		return f.set(w, false, real)
+	})
+}
@@ -0,0 +1,106 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestFloat_LambertW(t *testing.T) {
+	a := NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.LambertW(0, a)
+	t.Log(a.String())
+	if a.String() != "0.5671432904" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.LambertW(0, a)
+	t.Log(a.String())
+	if a.String() != "-0.3181315052 + 1.337235701i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-.2).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.LambertW(-1, a)
+	t.Log(a.String())
+	if a.String() != "-2.542641358" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(0).SetPrec(64))
+	a.LambertW(1, a)
+	t.Log(a.String())
+	if a.String() != "-1.53391332 + 4.375185153i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(1).SetPrec(64), big.NewFloat(1).SetPrec(64))
+	a.LambertW(0, a)
+	t.Log(a.String())
+	if a.String() != "0.6569660692 + 0.3254503394i" {
+		t.Fatal("invalid result")
+	}
+
+	a = NewFloat(big.NewFloat(-.36).SetPrec(64), big.NewFloat(.01).SetPrec(64))
+	a.LambertW(-1, a)
+	t.Log(a.String())
+	if a.String() != "-1.251437607 + -0.1360990741i" {
+		t.Fatal("invalid result")
+	}
+}
+
+func TestFloat_LambertWBranches(t *testing.T) {
+	for k := -5; k <= 5; k++ {
+		z := NewFloat(big.NewFloat(2).SetPrec(256), big.NewFloat(-3).SetPrec(256))
+		w := NewFloat(big.NewFloat(0).SetPrec(256), big.NewFloat(0).SetPrec(256))
+		w.LambertW(k, z)
+		e := NewFloat(big.NewFloat(0).SetPrec(256), big.NewFloat(0).SetPrec(256))
+		e.Exp(w)
+		e.Mul(e, w)
+		e.Sub(e, z)
+		if n := norm(e); n.Sign() != 0 && n.MantExp(nil) > -2*240 {
+			t.Fatal("invalid result", k, w.String(), e.String())
+		}
+	}
+}
+
+func TestFloat_LambertWBranchPoint(t *testing.T) {
+	// the 64 bit number nearest to -1/e, where Halley's method loses half of the bits
+	x := big.NewFloat(-0.36787944117144233).SetPrec(64)
+	for _, k := range []int{-1, 0} {
+		a := NewFloat(big.NewFloat(0).SetPrec(64).Set(x), big.NewFloat(0).SetPrec(64))
+		b := newFloat(512).LambertW(k, a)
+		a.LambertW(k, a)
+		if a.A.Cmp(b.A.SetPrec(64)) != 0 || a.B.Cmp(b.B.SetPrec(64)) != 0 {
+			t.Fatal("invalid result", k, a.A.Text('g', 25), b.A.Text('g', 25))
+		}
+	}
+}
+
+func TestFloat_LambertWImaginary(t *testing.T) {
+	// Im W_0 is in [-pi, pi], Im W_k in [2(k-1)pi, (2k+1)pi] for k > 0 and
+	// Im W_k in [(2k-1)pi, (2k+2)pi] for k < 0
+	points := [][2]float64{{2, -3}, {-.3, 1e-3}, {-.3, -1e-3}, {-5, 0}, {10, 10}, {-1e-3, 0}, {.5, 0}, {-100, -1}}
+	for k := -4; k <= 4; k++ {
+		lo, hi := float64(2*k-2), float64(2*k+1)
+		switch {
+		case k == 0:
+			lo, hi = -1, 1
+		case k < 0:
+			lo, hi = float64(2*k-1), float64(2*k+2)
+		}
+		for _, p := range points {
+			z := NewFloat(big.NewFloat(p[0]).SetPrec(64), big.NewFloat(p[1]).SetPrec(64))
+			w := newFloat(64).LambertW(k, z)
+			b, _ := w.B.Float64()
+			if b < lo*math.Pi || b > hi*math.Pi {
+				t.Fatal("invalid branch", k, z.String(), w.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,323 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// nttThreshold is the least length of both sequences for which a convolution uses the number
+// theoretic transform instead of the schoolbook method
+const nttThreshold = 32
+
+// nttOrder is the power of two that divides p - 1 for every prime p of the number theoretic
+// transform, the longest transform has the length 2^nttOrder
+const nttOrder = 32
+
+// nttPrime is a prime p = c 2^nttOrder + 1 between 2^61 and 2^62 and a generator g of the
+// multiplicative group modulo p
+type nttPrime struct {
+	p, g uint64
+}
+
+var (
+	nttMutex  sync.Mutex
+	nttPrimes []nttPrime
+)
+
+// mulMod computes ab mod p for a, b < p
+func mulMod(a, b, p uint64) uint64 {
+	hi, lo := bits.Mul64(a, b)
+	_, r := bits.Div64(hi, lo, p)
+	return r
+}
+
+// powMod computes a^e mod p
+func powMod(a, e, p uint64) uint64 {
+	y := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			y = mulMod(y, a, p)
+		}
+		a = mulMod(a, a, p)
+	}
+	return y
+}
+
+// primes returns the first n primes of the number theoretic transform in decreasing order,
+// the primes are found once and must not be changed
+func primes(n int) []nttPrime {
+	nttMutex.Lock()
+	defer nttMutex.Unlock()
+	c := uint64(1<<(62-nttOrder)) - 1
+	if len(nttPrimes) > 0 {
+		c = nttPrimes[len(nttPrimes)-1].p>>nttOrder - 1
+	}
+	for ; len(nttPrimes) < n; c-- {
+		if c < 1<<(61-nttOrder) {
+			panic("too many primes")
+		}
+		p := c<<nttOrder + 1
+		if !big.NewInt(0).SetUint64(p).ProbablyPrime(20) {
+			continue
+		}
+		// the prime factors of p - 1 = c 2^nttOrder
+		factors, m := []uint64{2}, c
+		for q := uint64(2); q*q <= m; q++ {
+			if m%q == 0 {
+				if q != 2 {
+					factors = append(factors, q)
+				}
+				for m%q == 0 {
+					m /= q
+				}
+			}
+		}
+		if m > 2 {
+			factors = append(factors, m)
+		}
+		// g is a generator if g^((p-1)/q) != 1 for every prime factor q of p - 1
+		for g := uint64(2); ; g++ {
+			generator := true
+			for _, q := range factors {
+				if powMod(g, (p-1)/q, p) == 1 {
+					generator = false
+					break
+				}
+			}
+			if generator {
+				nttPrimes = append(nttPrimes, nttPrime{p, g})
+				break
+			}
+		}
+	}
+	return nttPrimes[:n]
+}
+
+// ntt computes the number theoretic transform of a with a power of two length modulo the
+// prime in place, with the inverse root of unity for the inverse, which isn't scaled
+// https://en.wikipedia.org/wiki/Discrete_Fourier_transform_over_a_ring
+func ntt(a []uint64, prime nttPrime, inverse bool) {
+	n, p := len(a), prime.p
+	shift := 64 - bits.TrailingZeros(uint(n))
+	for i := range a {
+		if j := int(bits.Reverse64(uint64(i)) >> shift); n > 1 && i < j {
+			a[i], a[j] = a[j], a[i]
+		}
+	}
+	for m := 2; m <= n; m <<= 1 {
+		w := powMod(prime.g, (p-1)/uint64(m), p)
+		if inverse {
+			w = powMod(w, p-2, p)
+		}
+		// the powers of the root of unity of order m
+		roots := make([]uint64, m/2)
+		roots[0] = 1
+		for k := 1; k < len(roots); k++ {
+			roots[k] = mulMod(roots[k-1], w, p)
+		}
+		for i := 0; i < n; i += m {
+			for k, root := range roots {
+				x, y := a[i+k], mulMod(a[i+k+m/2], root, p)
+				a[i+k] = x + y
+				if a[i+k] >= p {
+					a[i+k] -= p
+				}
+				a[i+k+m/2] = x + p - y
+				if a[i+k+m/2] >= p {
+					a[i+k+m/2] -= p
+				}
+			}
+		}
+	}
+}
+
+// residues computes x mod p for each x
+func residues(x []*big.Int, p uint64, n int) []uint64 {
+	y := make([]uint64, n)
+	m, r := big.NewInt(0).SetUint64(p), big.NewInt(0)
+	for i := range x {
+		y[i] = r.Mod(x[i], m).Uint64()
+	}
+	return y
+}
+
+// maxBits computes the largest bit length of the absolute values of x
+func maxBits(x []*big.Int) int {
+	n := 0
+	for i := range x {
+		if b := x[i].BitLen(); b > n {
+			n = b
+		}
+	}
+	return n
+}
+
+// schoolbook computes the convolution of a and b with the schoolbook method
+func schoolbook(a, b []*big.Int) []*big.Int {
+	c := make([]*big.Int, len(a)+len(b)-1)
+	for i := range c {
+		c[i] = big.NewInt(0)
+	}
+	t := big.NewInt(0)
+	for i := range a {
+		for j := range b {
+			c[i+j].Add(c[i+j], t.Mul(a[i], b[j]))
+		}
+	}
+	return c
+}
+
+// Convolve computes the exact convolution c_k = sum a_i b_(k-i) of two integer sequences, the
+// convolution is computed with number theoretic transforms modulo enough primes to bound the
+// coefficients and reconstructed with Garner's algorithm for the Chinese remainder theorem
+// https://en.wikipedia.org/wiki/Sch%C3%B6nhage%E2%80%93Strassen_algorithm
+// https://en.wikipedia.org/wiki/Chinese_remainder_theorem#Garner's_algorithm
+func Convolve(a, b []*big.Int) []*big.Int {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	if len(a) < nttThreshold || len(b) < nttThreshold {
+		return schoolbook(a, b)
+	}
+	size := len(a) + len(b) - 1
+	n := 1 << bits.Len(uint(size-1))
+	if n > 1<<nttOrder {
+		panic("convolution too long")
+	}
+	// |c_k| < 2^(bits(a) + bits(b)) min(len(a), len(b)) and the primes are larger than 2^61,
+	// with one more bit for the sign
+	length := len(a)
+	if len(b) < length {
+		length = len(b)
+	}
+	bound := maxBits(a) + maxBits(b) + bits.Len(uint(length)) + 1
+	ps := primes((bound + 60) / 61)
+
+	remainders := make([][]uint64, len(ps))
+	for i, prime := range ps {
+		x, y := residues(a, prime.p, n), residues(b, prime.p, n)
+		ntt(x, prime, false)
+		ntt(y, prime, false)
+		for j := range x {
+			x[j] = mulMod(x[j], y[j], prime.p)
+		}
+		ntt(x, prime, true)
+		scale := powMod(uint64(n), prime.p-2, prime.p)
+		for j := range x[:size] {
+			x[j] = mulMod(x[j], scale, prime.p)
+		}
+		remainders[i] = x
+	}
+
+	// the inverses of p_0 p_1 ... p_(i-1) modulo p_i and the product of the primes
+	inverses := make([]uint64, len(ps))
+	modulus := big.NewInt(1)
+	for i, prime := range ps {
+		product := uint64(1)
+		for _, q := range ps[:i] {
+			product = mulMod(product, q.p%prime.p, prime.p)
+		}
+		inverses[i] = powMod(product, prime.p-2, prime.p)
+		modulus.Mul(modulus, big.NewInt(0).SetUint64(prime.p))
+	}
+	half := big.NewInt(0).Rsh(modulus, 1)
+
+	c := make([]*big.Int, size)
+	v := make([]uint64, len(ps))
+	for k := range c {
+		// c_k = v_0 + p_0 (v_1 + p_1 (v_2 + ...)) with the mixed radix digits v_i
+		for i, prime := range ps {
+			p := prime.p
+			x := uint64(0)
+			for j := i - 1; j >= 0; j-- {
+				x = mulMod(x, ps[j].p%p, p) + v[j]%p
+				if x >= p {
+					x -= p
+				}
+			}
+			x = remainders[i][k] + p - x
+			if x >= p {
+				x -= p
+			}
+			v[i] = mulMod(x, inverses[i], p)
+		}
+		x, t := big.NewInt(0), big.NewInt(0)
+		for i := len(ps) - 1; i >= 0; i-- {
+			x.Mul(x, t.SetUint64(ps[i].p))
+			x.Add(x, t.SetUint64(v[i]))
+		}
+		if x.Cmp(half) > 0 {
+			x.Sub(x, modulus)
+		}
+		c[k] = x
+	}
+	return c
+}
+
+// ConvolveGaussian computes the exact convolution of two sequences of Gaussian integers with
+// the three integer convolutions ac, bd and (a + b)(c + d) of the real and imaginary parts
+// https://en.wikipedia.org/wiki/Multiplication_algorithm#Complex_number_multiplication
+func ConvolveGaussian(a, b []*GaussianInt) []*GaussianInt {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	parts := func(x []*GaussianInt) (re, im, sum []*big.Int) {
+		for i := range x {
+			re, im = append(re, x[i].A), append(im, x[i].B)
+			sum = append(sum, big.NewInt(0).Add(x[i].A, x[i].B))
+		}
+		return re, im, sum
+	}
+	ar, ai, as := parts(a)
+	br, bi, bs := parts(b)
+	rr, ii, ss := Convolve(ar, br), Convolve(ai, bi), Convolve(as, bs)
+	c := make([]*GaussianInt, len(rr))
+	for k := range c {
+		c[k] = NewGaussianInt(big.NewInt(0).Sub(rr[k], ii[k]), ss[k].Sub(ss[k], rr[k]))
+		c[k].B.Sub(c[k].B, ii[k])
+	}
+	return c
+}
+
+// gaussianScale computes the least common multiple d of the denominators of x and the
+// Gaussian integers dx
+func gaussianScale(x []*Rational) (*big.Int, []*GaussianInt) {
+	d := big.NewInt(1)
+	g := big.NewInt(0)
+	for i := range x {
+		for _, part := range []*big.Rat{x[i].A, x[i].B} {
+			g.GCD(nil, nil, d, part.Denom())
+			d.Mul(d, g.Quo(part.Denom(), g))
+		}
+	}
+	y := make([]*GaussianInt, len(x))
+	for i := range x {
+		y[i] = NewGaussianInt(big.NewInt(0).Mul(x[i].A.Num(), d), big.NewInt(0).Mul(x[i].B.Num(), d))
+		y[i].A.Quo(y[i].A, x[i].A.Denom())
+		y[i].B.Quo(y[i].B, x[i].B.Denom())
+	}
+	return d, y
+}
+
+// ConvolveRational computes the exact convolution of two sequences of Rational numbers from
+// the convolution of the Gaussian integers that are the sequences scaled by the least common
+// multiples of their denominators
+func ConvolveRational(a, b []*Rational) []*Rational {
+	if len(a) == 0 || len(b) == 0 {
+		return nil
+	}
+	da, x := gaussianScale(a)
+	db, y := gaussianScale(b)
+	d := big.NewInt(0).Mul(da, db)
+	z := ConvolveGaussian(x, y)
+	c := make([]*Rational, len(z))
+	for k := range c {
+		c[k] = NewRational(big.NewRat(0, 1).SetFrac(z[k].A, d), big.NewRat(0, 1).SetFrac(z[k].B, d))
+	}
+	return c
+}
@@ -0,0 +1,109 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"math/rand"
+	"testing"
+)
+
+// nttInts creates n random integers with up to the given number of bits and random signs
+func nttInts(rng *rand.Rand, n, bits int) []*big.Int {
+	x := make([]*big.Int, n)
+	for i := range x {
+		x[i] = big.NewInt(0).Rand(rng, big.NewInt(0).Lsh(big.NewInt(1), uint(rng.Intn(bits)+1)))
+		if rng.Intn(2) == 0 {
+			x[i].Neg(x[i])
+		}
+	}
+	return x
+}
+
+func TestPrimes(t *testing.T) {
+	for _, prime := range primes(4) {
+		p := big.NewInt(0).SetUint64(prime.p)
+		if !p.ProbablyPrime(20) || prime.p%(1<<nttOrder) != 1 || p.BitLen() != 62 {
+			t.Fatal("invalid prime", prime.p)
+		}
+		// the generator isn't a square
+		if powMod(prime.g, (prime.p-1)/2, prime.p) != prime.p-1 {
+			t.Fatal("invalid generator", prime.g)
+		}
+	}
+}
+
+func TestConvolve(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, test := range []struct{ a, b, bits int }{{1, 5, 8}, {40, 40, 8}, {100, 33, 200}, {257, 300, 1000}} {
+		a, b := nttInts(rng, test.a, test.bits), nttInts(rng, test.b, test.bits)
+		c, d := Convolve(a, b), schoolbook(a, b)
+		if len(c) != len(d) {
+			t.Fatal("invalid length", len(c))
+		}
+		for k := range c {
+			if c[k].Cmp(d[k]) != 0 {
+				t.Fatal("invalid convolution", test, k, c[k].String(), d[k].String())
+			}
+		}
+	}
+	if c := Convolve(nil, nttInts(rng, 3, 8)); c != nil {
+		t.Fatal("invalid empty convolution", c)
+	}
+}
+
+func TestConvolveGaussian(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	gaussian := func(n int) []*GaussianInt {
+		x, y := nttInts(rng, n, 100), nttInts(rng, n, 100)
+		z := make([]*GaussianInt, n)
+		for i := range z {
+			z[i] = NewGaussianInt(x[i], y[i])
+		}
+		return z
+	}
+	a, b := gaussian(50), gaussian(70)
+	c := ConvolveGaussian(a, b)
+	for k := range c {
+		d, t0 := newGaussianInt(0, 0), newGaussianInt(0, 0)
+		for i := range a {
+			if j := k - i; j >= 0 && j < len(b) {
+				d.Add(d, t0.Mul(a[i], b[j]))
+			}
+		}
+		if c[k].A.Cmp(d.A) != 0 || c[k].B.Cmp(d.B) != 0 {
+			t.Fatal("invalid convolution", k)
+		}
+	}
+}
+
+func TestPolynomial_MulNTT(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	rational := func(n int) Polynomial[*Rational] {
+		values := make([]*Rational, n)
+		for i := range values {
+			values[i] = NewRational(big.NewRat(rng.Int63n(2001)-1000, rng.Int63n(30)+1), big.NewRat(rng.Int63n(2001)-1000, rng.Int63n(30)+1))
+		}
+		return NewPolynomial[*Rational](RationalField{}, values...)
+	}
+	a, b := rational(100), rational(64)
+	c := NewPolynomial[*Rational](RationalField{})
+	c.Mul(&a, &b)
+
+	// the schoolbook product
+	field := RationalField{}
+	values := make([]*Rational, a.Degree()+b.Degree()+1)
+	for i := range values {
+		values[i] = field.Zero()
+	}
+	for i, x := range a.Coefficients {
+		for j, y := range b.Coefficients {
+			values[i+j] = field.Add(values[i+j], field.Mul(x, y))
+		}
+	}
+	if d := NewPolynomial[*Rational](field, values...); c.String() != d.String() {
+		t.Fatal("invalid product", c.String())
+	}
+}
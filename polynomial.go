@@ -0,0 +1,283 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"fmt"
+)
+
+// Polynomial is a polynomial c0 + c1 x + ... + cn x^n over a field, the coefficients are in
+// increasing order of degree without zero leading coefficients, so the zero polynomial has
+// no coefficients. The arithmetic is exact over RationalField, which is Q(i). The receiver of
+// a method may alias the operands, which are never changed
+// https://en.wikipedia.org/wiki/Polynomial_ring
+type Polynomial[T any] struct {
+	Field        Field[T]
+	Coefficients []T
+}
+
+// NewPolynomial creates a new polynomial over the field from the coefficients in increasing
+// order of degree
+func NewPolynomial[T any](field Field[T], coefficients ...T) Polynomial[T] {
+	p := Polynomial[T]{}
+	values := make([]T, len(coefficients))
+	for i := range coefficients {
+		values[i] = field.Add(field.Zero(), coefficients[i])
+	}
+	p.set(field, values)
+	return p
+}
+
+// NewRationalPolynomial creates a new polynomial over the Rational numbers from the
+// coefficients in increasing order of degree
+func NewRationalPolynomial(coefficients []Rational) Polynomial[*Rational] {
+	values := make([]*Rational, len(coefficients))
+	for i := range coefficients {
+		values[i] = &coefficients[i]
+	}
+	return NewPolynomial[*Rational](RationalField{}, values...)
+}
+
+// set sets p to the coefficients and removes the zero leading coefficients
+func (p *Polynomial[T]) set(field Field[T], coefficients []T) *Polynomial[T] {
+	for len(coefficients) > 0 && field.IsZero(coefficients[len(coefficients)-1]) {
+		coefficients = coefficients[:len(coefficients)-1]
+	}
+	p.Field, p.Coefficients = field, coefficients
+	return p
+}
+
+// coefficient returns the coefficient of x^i
+func (p *Polynomial[T]) coefficient(i int) T {
+	if i < len(p.Coefficients) {
+		return p.Coefficients[i]
+	}
+	return p.Field.Zero()
+}
+
+// copy copies the coefficients of p
+func (p *Polynomial[T]) copy() []T {
+	values := make([]T, len(p.Coefficients))
+	for i := range values {
+		values[i] = p.Field.Add(p.Field.Zero(), p.Coefficients[i])
+	}
+	return values
+}
+
+// Degree returns the degree of p, the degree of the zero polynomial is -1
+func (p *Polynomial[T]) Degree() int {
+	return len(p.Coefficients) - 1
+}
+
+// IsZero determines if p is the zero polynomial
+func (p *Polynomial[T]) IsZero() bool {
+	return len(p.Coefficients) == 0
+}
+
+// Lead returns the leading coefficient of p, which is 0 for the zero polynomial
+func (p *Polynomial[T]) Lead() T {
+	return p.coefficient(p.Degree())
+}
+
+// Eval evaluates p at x with Horner's method
+// https://en.wikipedia.org/wiki/Horner%27s_method
+func (p *Polynomial[T]) Eval(x T) T {
+	field, y := p.Field, p.Field.Zero()
+	for i := len(p.Coefficients) - 1; i >= 0; i-- {
+		y = field.Add(field.Mul(y, x), p.Coefficients[i])
+	}
+	return y
+}
+
+// Add adds two polynomials
+func (p *Polynomial[T]) Add(a, b *Polynomial[T]) *Polynomial[T] {
+	n := len(a.Coefficients)
+	if len(b.Coefficients) > n {
+		n = len(b.Coefficients)
+	}
+	values := make([]T, n)
+	for i := range values {
+		values[i] = a.Field.Add(a.coefficient(i), b.coefficient(i))
+	}
+	return p.set(a.Field, values)
+}
+
+// Sub subtracts two polynomials
+func (p *Polynomial[T]) Sub(a, b *Polynomial[T]) *Polynomial[T] {
+	n := len(a.Coefficients)
+	if len(b.Coefficients) > n {
+		n = len(b.Coefficients)
+	}
+	values := make([]T, n)
+	for i := range values {
+		values[i] = a.Field.Add(a.coefficient(i), a.Field.Neg(b.coefficient(i)))
+	}
+	return p.set(a.Field, values)
+}
+
+// Neg negates a polynomial
+func (p *Polynomial[T]) Neg(a *Polynomial[T]) *Polynomial[T] {
+	values := make([]T, len(a.Coefficients))
+	for i := range values {
+		values[i] = a.Field.Neg(a.Coefficients[i])
+	}
+	return p.set(a.Field, values)
+}
+
+// Mul multiplies two polynomials, long polynomials over RationalField are multiplied with
+// ConvolveRational
+func (p *Polynomial[T]) Mul(a, b *Polynomial[T]) *Polynomial[T] {
+	field := a.Field
+	if a.IsZero() || b.IsZero() {
+		return p.set(field, nil)
+	}
+	if _, ok := any(field).(RationalField); ok && len(a.Coefficients) >= nttThreshold && len(b.Coefficients) >= nttThreshold {
+		values := ConvolveRational(any(a.Coefficients).([]*Rational), any(b.Coefficients).([]*Rational))
+		return p.set(field, any(values).([]T))
+	}
+	values := make([]T, len(a.Coefficients)+len(b.Coefficients)-1)
+	for i := range values {
+		values[i] = field.Zero()
+	}
+	for i, x := range a.Coefficients {
+		for j, y := range b.Coefficients {
+			values[i+j] = field.Add(values[i+j], field.Mul(x, y))
+		}
+	}
+	return p.set(field, values)
+}
+
+// Monic divides a by its leading coefficient, the zero polynomial is unchanged
+func (p *Polynomial[T]) Monic(a *Polynomial[T]) *Polynomial[T] {
+	if a.IsZero() {
+		return p.set(a.Field, nil)
+	}
+	field, values := a.Field, make([]T, len(a.Coefficients))
+	inv := field.Inv(a.Lead())
+	for i := range values {
+		values[i] = field.Mul(a.Coefficients[i], inv)
+	}
+	values[len(values)-1] = field.One()
+	return p.set(field, values)
+}
+
+// QuoRem sets p to the quotient of the Euclidean division of a by b and returns the quotient
+// and the remainder r = a - b(a/b), which has a degree less than the degree of b.
+// QuoRem panics if b is 0
+// https://en.wikipedia.org/wiki/Polynomial_long_division
+func (p *Polynomial[T]) QuoRem(a, b *Polynomial[T]) (*Polynomial[T], *Polynomial[T]) {
+	if b.IsZero() {
+		panic("division by zero")
+	}
+	field, r := a.Field, a.copy()
+	n := b.Degree()
+	if len(r) <= n {
+		return p.set(field, nil), (&Polynomial[T]{}).set(field, r)
+	}
+	q := make([]T, len(r)-n)
+	inv := field.Inv(b.Lead())
+	for k := len(r) - 1; k >= n; k-- {
+		c := field.Mul(r[k], inv)
+		q[k-n] = c
+		for i := 0; i < n; i++ {
+			r[k-n+i] = field.Add(r[k-n+i], field.Neg(field.Mul(c, b.Coefficients[i])))
+		}
+		// the leading coefficient cancels exactly
+		r = r[:k]
+	}
+	return p.set(field, q), (&Polynomial[T]{}).set(field, r)
+}
+
+// GCD sets p to the monic greatest common divisor of a and b, which is 0 if a and b are 0
+// https://en.wikipedia.org/wiki/Polynomial_greatest_common_divisor
+func (p *Polynomial[T]) GCD(a, b *Polynomial[T]) *Polynomial[T] {
+	x, y := &Polynomial[T]{}, &Polynomial[T]{}
+	x.set(a.Field, a.copy())
+	y.set(b.Field, b.copy())
+	for !y.IsZero() {
+		_, r := (&Polynomial[T]{}).QuoRem(x, y)
+		x, y = y, r
+	}
+	return p.Monic(x)
+}
+
+// Derivative computes the derivative of a
+func (p *Polynomial[T]) Derivative(a *Polynomial[T]) *Polynomial[T] {
+	field := a.Field
+	if a.Degree() < 1 {
+		return p.set(field, nil)
+	}
+	values := make([]T, len(a.Coefficients)-1)
+	k := field.Zero()
+	for i := range values {
+		k = field.Add(k, field.One())
+		values[i] = field.Mul(k, a.Coefficients[i+1])
+	}
+	return p.set(field, values)
+}
+
+// Compose computes the composition a(b(x)) with Horner's method
+// https://en.wikipedia.org/wiki/Function_composition
+func (p *Polynomial[T]) Compose(a, b *Polynomial[T]) *Polynomial[T] {
+	field, y := a.Field, &Polynomial[T]{Field: a.Field}
+	for i := len(a.Coefficients) - 1; i >= 0; i-- {
+		y.Mul(y, b)
+		c := NewPolynomial(field, a.Coefficients[i])
+		y.Add(y, &c)
+	}
+	return p.set(field, y.Coefficients)
+}
+
+// SquareFree computes the square-free factorization p = lead f1 f2^2 ... fk^k of a non zero
+// polynomial over a field of characteristic 0 with Yun's algorithm, the factors fi are monic,
+// pairwise coprime and square-free, and the factor of multiplicity i is factors[i-1], which is 1
+// if there is no factor of multiplicity i. SquareFree panics if p is 0
+// https://en.wikipedia.org/wiki/Square-free_polynomial#Yun's_algorithm
+func (p *Polynomial[T]) SquareFree() (lead T, factors []Polynomial[T]) {
+	if p.IsZero() {
+		panic("factorization of zero")
+	}
+	lead = p.Field.Add(p.Field.Zero(), p.Lead())
+	f := (&Polynomial[T]{}).Monic(p)
+	if f.Degree() == 0 {
+		return lead, nil
+	}
+	derivative := (&Polynomial[T]{}).Derivative(f)
+	a := (&Polynomial[T]{}).GCD(f, derivative)
+	b, _ := (&Polynomial[T]{}).QuoRem(f, a)
+	c, _ := (&Polynomial[T]{}).QuoRem(derivative, a)
+	d := (&Polynomial[T]{}).Sub(c, (&Polynomial[T]{}).Derivative(b))
+	for b.Degree() > 0 {
+		a := (&Polynomial[T]{}).GCD(b, d)
+		factors = append(factors, *a)
+		b.QuoRem(b, a)
+		c.QuoRem(d, a)
+		d.Sub(c, (&Polynomial[T]{}).Derivative(b))
+	}
+	return lead, factors
+}
+
+// String returns a string representation of the polynomial
+func (p *Polynomial[T]) String() string {
+	if p.IsZero() {
+		return "0"
+	}
+	s := ""
+	for i, c := range p.Coefficients {
+		if p.Field.IsZero(c) {
+			continue
+		}
+		if s != "" {
+			s += " + "
+		}
+		s += "(" + fmt.Sprint(c) + ")"
+		if i == 1 {
+			s += "x"
+		} else if i > 1 {
+			s += fmt.Sprintf("x^%d", i)
+		}
+	}
+	return s
+}
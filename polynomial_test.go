@@ -0,0 +1,152 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// rationalPolynomial creates a polynomial over the Rational numbers from pairs of integer parts
+func rationalPolynomial(parts ...int64) Polynomial[*Rational] {
+	return NewRationalPolynomial(rationals(1, parts...))
+}
+
+func TestPolynomial(t *testing.T) {
+	// x - 1, x + i and x - 2
+	a, b, c := rationalPolynomial(-1, 0, 1, 0), rationalPolynomial(0, 1, 1, 0), rationalPolynomial(-2, 0, 1, 0)
+	p := Polynomial[*Rational]{}
+	p.Mul(&a, &b)
+	if p.String() != "(0/1 + -1/1i) + (-1/1 + 1/1i)x + (1/1 + 0/1i)x^2" {
+		t.Fatal("invalid product", p.String())
+	}
+	if p.Degree() != 2 || p.Lead().String() != "1/1 + 0/1i" {
+		t.Fatal("invalid degree", p.Degree(), p.Lead().String())
+	}
+	if y := p.Eval(NewRational(big.NewRat(1, 1), big.NewRat(0, 1))); y.String() != "0/1 + 0/1i" {
+		t.Fatal("invalid value", y.String())
+	}
+	if y := p.Eval(NewRational(big.NewRat(2, 1), big.NewRat(1, 1))); y.String() != "0/1 + 4/1i" {
+		t.Fatal("invalid value", y.String())
+	}
+
+	sum := Polynomial[*Rational]{}
+	sum.Add(&p, &c)
+	sum.Sub(&sum, &p)
+	if sum.String() != c.String() {
+		t.Fatal("invalid sum", sum.String())
+	}
+	sum.Sub(&p, &p)
+	if !sum.IsZero() || sum.Degree() != -1 || sum.String() != "0" {
+		t.Fatal("invalid difference", sum.String())
+	}
+
+	q, r := (&Polynomial[*Rational]{}).QuoRem(&p, &c)
+	if q.String() != "(1/1 + 1/1i) + (1/1 + 0/1i)x" || r.String() != "(2/1 + 1/1i)" {
+		t.Fatal("invalid division", q.String(), r.String())
+	}
+	check := Polynomial[*Rational]{}
+	check.Mul(q, &c)
+	check.Add(&check, r)
+	if check.String() != p.String() {
+		t.Fatal("invalid division", check.String())
+	}
+
+	e := Polynomial[*Rational]{}
+	e.Mul(&p, &c)
+	g := Polynomial[*Rational]{}
+	g.GCD(&e, (&Polynomial[*Rational]{}).Mul(&b, &c))
+	if g.String() != "(0/1 + -2/1i) + (-2/1 + 1/1i)x + (1/1 + 0/1i)x^2" {
+		t.Fatal("invalid gcd", g.String())
+	}
+
+	d := Polynomial[*Rational]{}
+	d.Derivative(&e)
+	if d.String() != "(2/1 + -3/1i) + (-6/1 + 2/1i)x + (3/1 + 0/1i)x^2" {
+		t.Fatal("invalid derivative", d.String())
+	}
+
+	// p(x - 1) has the roots 2 and 1 - i
+	composition := Polynomial[*Rational]{}
+	composition.Compose(&p, &a)
+	if y := composition.Eval(NewRational(big.NewRat(1, 1), big.NewRat(-1, 1))); y.String() != "0/1 + 0/1i" {
+		t.Fatal("invalid composition", composition.String())
+	}
+	if y := composition.Eval(NewRational(big.NewRat(2, 1), big.NewRat(0, 1))); y.String() != "0/1 + 0/1i" {
+		t.Fatal("invalid composition", composition.String())
+	}
+}
+
+func TestPolynomial_SquareFree(t *testing.T) {
+	// 3i (x - 1) (x + i)^3 (x - 2)^4
+	a, b, c := rationalPolynomial(-1, 0, 1, 0), rationalPolynomial(0, 1, 1, 0), rationalPolynomial(-2, 0, 1, 0)
+	p := rationalPolynomial(0, 3)
+	p.Mul(&p, &a)
+	for i := 0; i < 3; i++ {
+		p.Mul(&p, &b)
+	}
+	for i := 0; i < 4; i++ {
+		p.Mul(&p, &c)
+	}
+	lead, factors := p.SquareFree()
+	if lead.String() != "0/1 + 3/1i" {
+		t.Fatal("invalid leading coefficient", lead.String())
+	}
+	expected := []string{a.String(), "(1/1 + 0/1i)", b.String(), c.String()}
+	if len(factors) != len(expected) {
+		t.Fatal("invalid number of factors", len(factors))
+	}
+	product := NewPolynomial[*Rational](RationalField{}, lead)
+	for i := range factors {
+		if factors[i].String() != expected[i] {
+			t.Fatal("invalid factor", i, factors[i].String())
+		}
+		for j := 0; j <= i; j++ {
+			product.Mul(&product, &factors[i])
+		}
+	}
+	if product.String() != p.String() {
+		t.Fatal("invalid factorization", product.String())
+	}
+
+	constant := rationalPolynomial(5, 0)
+	lead, factors = constant.SquareFree()
+	if lead.String() != "5/1 + 0/1i" || len(factors) != 0 {
+		t.Fatal("invalid factorization of a constant", lead.String(), len(factors))
+	}
+}
+
+func TestPolynomial_Float(t *testing.T) {
+	field := NewFloatField(64, big.ToNearestEven)
+	one, i := field.One(), field.Context.NewFloat(nil, big.NewFloat(1))
+	// (x - i)^2 = x^2 - 2ix - 1
+	a := NewPolynomial[*Float](field, field.Neg(i), one)
+	p := Polynomial[*Float]{}
+	p.Mul(&a, &a)
+	if p.String() != "(-1) + (0 + -2i)x + (1)x^2" {
+		t.Fatal("invalid product", p.String())
+	}
+	x := field.Context.NewFloat(big.NewFloat(.5), big.NewFloat(2))
+	if y := p.Eval(x); y.String() != "-0.75 + 1i" {
+		t.Fatal("invalid value", y.String())
+	}
+	q, r := (&Polynomial[*Float]{}).QuoRem(&p, &a)
+	if q.String() != a.String() || !r.IsZero() {
+		t.Fatal("invalid division", q.String(), r.String())
+	}
+}
+
+func TestPolynomial_Modular(t *testing.T) {
+	field := NewModularField(big.NewInt(7))
+	n := big.NewInt
+	// (x + 1)(x + 3) and (x + 1)(x + 5) mod 7
+	a := NewPolynomial[*big.Int](field, n(3), n(4), n(1))
+	b := NewPolynomial[*big.Int](field, n(5), n(6), n(1))
+	g := Polynomial[*big.Int]{}
+	g.GCD(&a, &b)
+	if g.String() != "(1) + (1)x" {
+		t.Fatal("invalid gcd", g.String())
+	}
+}
@@ -0,0 +1,305 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"sync"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// quadratureGuard is the number of guard bits used by the quadrature rules
+const quadratureGuard = 32
+
+// quadratureLevels is the largest number of halvings of the step of the tanh-sinh rule
+const quadratureLevels = 12
+
+// quadraturePoints is the largest number of points of the Gauss-Legendre rule
+const quadraturePoints = 512
+
+// quadratureNode is a node of a rule for [-1, 1] at the distance 2d from an endpoint, so the
+// node is x = 1 - 2d, with the weight w. The node -x has the same weight unless x = 0
+type quadratureNode struct {
+	d, w *big.Float
+}
+
+var (
+	quadratureMutex sync.Mutex
+	tanhSinhNodes   = make(map[[2]uint][]quadratureNode)
+	legendreNodes   = make(map[[2]uint][]quadratureNode)
+)
+
+// Integrator is a quadrature rule that integrates f over the real interval [a, b] and returns
+// the integral and an estimate of its error
+type Integrator func(f func(*Float) *Float, a, b *big.Float) (integral *Float, err *big.Float)
+
+// tanhSinh computes the nodes t = j 2^-level of the tanh-sinh rule with the given precision,
+// the odd j for level > 0, until the distance of the nodes from the endpoints is below
+// 2^(-2 prec). The nodes are x = tanh(pi/2 sinh(t)) with the distance d = 1/(e^(2u) + 1) for
+// u = pi/2 sinh(t) and the weight pi/2 cosh(t)/cosh(u)^2 = 2 pi cosh(t) e^(2u) d^2
+// https://en.wikipedia.org/wiki/Tanh-sinh_quadrature
+func tanhSinh(prec uint, level int) []quadratureNode {
+	quadratureMutex.Lock()
+	defer quadratureMutex.Unlock()
+	key := [2]uint{prec, uint(level)}
+	if nodes, ok := tanhSinhNodes[key]; ok {
+		return nodes
+	}
+	pi := bigfloat.PI(prec)
+	cut := big.NewFloat(0).SetMantExp(big.NewFloat(1), -2*int(prec))
+	one := big.NewFloat(1).SetPrec(prec)
+	var nodes []quadratureNode
+	for j := 0; ; j++ {
+		if level > 0 && j%2 == 0 {
+			continue
+		}
+		t := big.NewFloat(float64(j)).SetPrec(prec)
+		t.SetMantExp(t, -level)
+		sinh, cosh := sinhCosh(t, prec)
+		// e^(2u) = e^(pi sinh(t))
+		e := bigfloat.Exp(big.NewFloat(0).SetPrec(prec).Mul(pi, sinh))
+		d := big.NewFloat(0).SetPrec(prec).Add(e, one)
+		d.Quo(one, d)
+		if d.Cmp(cut) < 0 {
+			break
+		}
+		w := big.NewFloat(0).SetPrec(prec).Mul(pi, cosh)
+		w.Mul(w, e)
+		w.Mul(w, d)
+		w.Mul(w, d)
+		w.SetMantExp(w, 1)
+		nodes = append(nodes, quadratureNode{d, w})
+	}
+	tanhSinhNodes[key] = nodes
+	return nodes
+}
+
+// legendre computes the nodes of the Gauss-Legendre rule with n points with the given
+// precision, the roots x of the Legendre polynomial P_n are found with Newton's method from
+// cos(pi (i - 1/4)/(n + 1/2)) and the weights are 2/((1 - x^2) P_n'(x)^2)
+// https://en.wikipedia.org/wiki/Gauss%E2%80%93Legendre_quadrature
+func legendre(n int, prec uint) []quadratureNode {
+	quadratureMutex.Lock()
+	defer quadratureMutex.Unlock()
+	key := [2]uint{uint(n), prec}
+	if nodes, ok := legendreNodes[key]; ok {
+		return nodes
+	}
+	number := func() *big.Float {
+		return big.NewFloat(0).SetPrec(prec)
+	}
+	// p computes P_n(x) and P_n'(x) = n (x P_n(x) - P_(n-1)(x))/(x^2 - 1)
+	p := func(x *big.Float) (y, dy *big.Float) {
+		y0, y1, t := number().SetInt64(1), number().Set(x), number()
+		for k := 1; k < n; k++ {
+			t.Mul(x, y1)
+			t.Mul(t, number().SetInt64(int64(2*k+1)))
+			t.Sub(t, number().Mul(y0, number().SetInt64(int64(k))))
+			t.Quo(t, number().SetInt64(int64(k+1)))
+			y0, y1, t = y1, t, y0
+		}
+		dy = number().Mul(x, y1)
+		dy.Sub(dy, y0)
+		dy.Mul(dy, number().SetInt64(int64(n)))
+		xx := number().Mul(x, x)
+		return y1, dy.Quo(dy, xx.Sub(xx, number().SetInt64(1)))
+	}
+	eps := big.NewFloat(0).SetMantExp(big.NewFloat(1), 8-int(prec))
+	var nodes []quadratureNode
+	for i := 1; 2*i <= n; i++ {
+		x := number().SetFloat64(math.Cos(math.Pi * (float64(i) - .25) / (float64(n) + .5)))
+		var dy *big.Float
+		for k := 0; k < 64; k++ {
+			var y *big.Float
+			y, dy = p(x)
+			dx := number().Quo(y, dy)
+			x.Sub(x, dx)
+			if dx.Abs(dx).Cmp(eps) <= 0 {
+				break
+			}
+		}
+		_, dy = p(x)
+		// 1 - x = 2d and the weight is 2/((1 - x)(1 + x) P_n'(x)^2)
+		d := number().Sub(number().SetInt64(1), x)
+		w := number().Add(number().SetInt64(1), x)
+		w.Mul(w, d)
+		w.Mul(w, dy)
+		w.Mul(w, dy)
+		w.Quo(number().SetInt64(2), w)
+		nodes = append(nodes, quadratureNode{d.SetMantExp(d, -1), w})
+	}
+	legendreNodes[key] = nodes
+	return nodes
+}
+
+// quadrature sums w f(x) for the nodes mapped from [-1, 1] to [a, b] with the given
+// precision and returns the sum and the sum of the absolute values of the parts. The nodes
+// near the endpoints are computed with the precision that separates them from the endpoints,
+// and the values of f that aren't finite are skipped when skip is true
+func quadrature(f func(*Float) *Float, a, b *big.Float, nodes []quadratureNode, center, skip bool, prec uint) (*Float, *big.Float) {
+	width := big.NewFloat(0).SetPrec(prec).Sub(b, a)
+	half := big.NewFloat(0).SetPrec(prec).SetMantExp(width, -1)
+	sum, magnitude := newFloat(prec), big.NewFloat(0).SetPrec(prec)
+	t := newFloat(prec)
+	add := func(x *big.Float, w *big.Float) {
+		y := f(NewFloat(x, big.NewFloat(0).SetPrec(x.Prec())))
+		if skip && (y.IsInf() || y.IsNaN()) {
+			return
+		}
+		t.Mul(y, NewFloat(w, big.NewFloat(0).SetPrec(prec)))
+		sum.Add(sum, t)
+		magnitude.Add(magnitude, big.NewFloat(0).SetPrec(prec).Abs(t.A))
+		magnitude.Add(magnitude, big.NewFloat(0).SetPrec(prec).Abs(t.B))
+	}
+	for i, node := range nodes {
+		xp := prec
+		if e := node.d.MantExp(nil); e < 0 {
+			xp += uint(-e)
+		}
+		offset := big.NewFloat(0).SetPrec(prec).Mul(width, node.d)
+		w := big.NewFloat(0).SetPrec(prec).Mul(node.w, half)
+		if center && i == 0 {
+			add(big.NewFloat(0).SetPrec(xp).Sub(b, offset), w)
+			continue
+		}
+		add(big.NewFloat(0).SetPrec(xp).Sub(b, offset), w)
+		add(big.NewFloat(0).SetPrec(xp).Add(a, offset), w)
+	}
+	return sum, magnitude
+}
+
+// converged determines the difference of two approximations of an integral and if it is
+// within 2^-prec of the magnitude of the parts of the sum
+func converged(x, y *Float, magnitude *big.Float, prec uint) (*big.Float, bool) {
+	if x.IsInf() || x.IsNaN() || y.IsInf() || y.IsNaN() {
+		return big.NewFloat(math.Inf(1)), false
+	}
+	d := modulus(newFloat(x.A.Prec()).Sub(x, y), 64)
+	tolerance := big.NewFloat(0).SetMantExp(magnitude, -int(prec))
+	return d, d.Cmp(tolerance) <= 0
+}
+
+// estimate rounds the integral to the context and adds the rounding error to the error
+func (c *Context) estimate(integral *Float, err *big.Float) (*Float, *big.Float) {
+	y := c.NewFloat(integral.A, integral.B)
+	if err.IsInf() {
+		return y, err
+	}
+	r := modulus(integral, 64)
+	r.SetMantExp(r, -int(c.Prec))
+	return y, r.Add(r, err)
+}
+
+// TanhSinh integrates f over [a, b] with the tanh-sinh rule and halves the step until two
+// approximations agree to the precision of the context. The error estimate is the difference
+// of the last two approximations, and the values of f that aren't finite at the nodes near
+// the endpoints are skipped, so integrable singularities at the endpoints are allowed. f is
+// called with arguments that have at least the working precision and should compute its
+// result with the precision of its argument
+// https://en.wikipedia.org/wiki/Tanh-sinh_quadrature
+func (c *Context) TanhSinh(f func(*Float) *Float, a, b *big.Float) (*Float, *big.Float) {
+	wp := c.Prec + quadratureGuard
+	sum, magnitude := newFloat(wp), big.NewFloat(0).SetPrec(wp)
+	var previous *Float
+	err := big.NewFloat(math.Inf(1))
+	for level := 0; level <= quadratureLevels; level++ {
+		s, m := quadrature(f, a, b, tanhSinh(wp, level), level == 0, true, wp)
+		sum.Add(sum, s)
+		magnitude.Add(magnitude, m)
+		integral := sum.clone(wp)
+		integral.A.SetMantExp(integral.A, -level)
+		integral.B.SetMantExp(integral.B, -level)
+		if previous != nil {
+			var ok bool
+			m := big.NewFloat(0).SetMantExp(magnitude, -level)
+			if err, ok = converged(integral, previous, m, c.Prec); ok && level >= 3 {
+				return c.estimate(integral, err)
+			}
+		}
+		previous = integral
+	}
+	return c.estimate(previous, err)
+}
+
+// GaussLegendre integrates f over [a, b] with the Gauss-Legendre rule and doubles the number
+// of points from 8 until two approximations agree to the precision of the context. The error
+// estimate is the difference of the last two approximations. The nodes are computed once for
+// each precision and number of points. f is called with arguments that have the working
+// precision and should compute its result with the precision of its argument
+// https://en.wikipedia.org/wiki/Gauss%E2%80%93Legendre_quadrature
+func (c *Context) GaussLegendre(f func(*Float) *Float, a, b *big.Float) (*Float, *big.Float) {
+	wp := c.Prec + quadratureGuard
+	var previous *Float
+	err := big.NewFloat(math.Inf(1))
+	for n := 8; n <= quadraturePoints; n *= 2 {
+		integral, magnitude := quadrature(f, a, b, legendre(n, wp), false, false, wp)
+		if previous != nil {
+			var ok bool
+			if err, ok = converged(integral, previous, magnitude, c.Prec); ok {
+				return c.estimate(integral, err)
+			}
+		}
+		previous = integral
+	}
+	return c.estimate(previous, err)
+}
+
+// Contour integrates f along the contour z(t) for t in [a, b] with the quadrature rule, the
+// integral of f(z(t)) z'(t) where dz computes z'(t)
+// https://en.wikipedia.org/wiki/Contour_integration
+func Contour(rule Integrator, f, z, dz func(*Float) *Float, a, b *big.Float) (*Float, *big.Float) {
+	return rule(func(t *Float) *Float {
+		y := f(z(t))
+		return newFloat(y.A.Prec()).Mul(y, dz(t))
+	}, a, b)
+}
+
+// circle integrates f(z) (z - center)/(2 pi) dt along the circle z = center + r e^(it) for t in
+// [0, 2 pi], which is the integral of f dz/(2 pi i), with the Gauss-Legendre rule
+func (c *Context) circle(f func(*Float) *Float, center *Float, radius *big.Float) (*Float, *big.Float) {
+	wp := c.Prec + quadratureGuard
+	tau := bigfloat.PI(wp)
+	tau.SetMantExp(tau, 1)
+	integral, err := c.GaussLegendre(func(t *Float) *Float {
+		prec := t.A.Prec()
+		sin, cos := sinCos(t.A, prec)
+		r := NewFloat(cos.Mul(cos, radius), sin.Mul(sin, radius))
+		z := newFloat(prec).Add(center, r)
+		return newFloat(prec).Mul(f(z), r)
+	}, big.NewFloat(0).SetPrec(wp), tau)
+	scale := newFloat(wp)
+	scale.A.Quo(big.NewFloat(1).SetPrec(wp), tau)
+	integral.Mul(integral, scale)
+	return integral, err.Quo(err, tau)
+}
+
+// Residue computes the sum of the residues of f inside the circle with the center and the
+// radius, the contour integral of f/(2 pi i) along the circle, and an estimate of the error.
+// f must be analytic on the circle
+// https://en.wikipedia.org/wiki/Residue_theorem
+func (c *Context) Residue(f func(*Float) *Float, center *Float, radius *big.Float) (*Float, *big.Float) {
+	return c.circle(f, center, radius)
+}
+
+// CountZeros counts the zeros minus the poles of f inside the circle with the center and the
+// radius with the argument principle, the contour integral of f'/(2 pi i f) along the
+// circle, where df computes f'. ok is false if the integral isn't within 1/2 of an integer
+// with the error estimate, which happens if f has a zero or a pole near the circle
+// https://en.wikipedia.org/wiki/Argument_principle
+func (c *Context) CountZeros(f, df func(*Float) *Float, center *Float, radius *big.Float) (n int, ok bool) {
+	integral, err := c.circle(func(z *Float) *Float {
+		y := df(z)
+		return newFloat(y.A.Prec()).Div(y, f(z))
+	}, center, radius)
+	if err.IsInf() || integral.IsInf() || integral.IsNaN() {
+		return 0, false
+	}
+	rounded, _ := big.NewFloat(0).Add(integral.A, big.NewFloat(math.Copysign(.5, float64(integral.A.Sign())))).Int64()
+	d := newFloat(integral.A.Prec()).Sub(integral, NewFloat(big.NewFloat(float64(rounded)), big.NewFloat(0)))
+	distance := modulus(d, 64)
+	return int(rounded), distance.Add(distance, err).Cmp(big.NewFloat(.5)) < 0
+}
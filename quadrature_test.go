@@ -0,0 +1,159 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ALTree/bigfloat"
+)
+
+// quadratureCheck determines if the integral is within the error of the exact value and the
+// error is below 2^-bound
+func quadratureCheck(integral *Float, err *big.Float, exact *Float, bound int) bool {
+	d := modulus(newFloat(256).Sub(integral, exact), 64)
+	return d.Cmp(err) <= 0 && err.Cmp(big.NewFloat(0).SetMantExp(big.NewFloat(1), -bound)) < 0
+}
+
+func TestContext_TanhSinh(t *testing.T) {
+	c := NewContext(128, big.ToNearestEven)
+	zero, one := big.NewFloat(0), big.NewFloat(1)
+
+	// the integral of 1/sqrt(x) over [0, 1] is 2
+	integral, err := c.TanhSinh(func(x *Float) *Float {
+		y := newFloat(x.A.Prec()).Sqrt(x)
+		return y.Div(newReal(1, x.A.Prec()), y)
+	}, zero, one)
+	if !quadratureCheck(integral, err, newReal(2, 128), 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+
+	// the integral of log(x) over [0, 1] is -1
+	integral, err = c.TanhSinh(func(x *Float) *Float {
+		return newFloat(x.A.Prec()).Log(x)
+	}, zero, one)
+	if !quadratureCheck(integral, err, newReal(-1, 128), 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+
+	// the integral of 4/(1 + x^2) over [0, 1] is pi
+	integral, err = c.TanhSinh(func(x *Float) *Float {
+		y := newFloat(x.A.Prec()).Mul(x, x)
+		y.Add(y, newReal(1, x.A.Prec()))
+		return y.Div(newReal(4, x.A.Prec()), y)
+	}, zero, one)
+	if !quadratureCheck(integral, err, NewFloat(bigfloat.PI(256), big.NewFloat(0)), 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+}
+
+func TestContext_GaussLegendre(t *testing.T) {
+	c := NewContext(128, big.ToNearestEven)
+
+	// the integral of e^x over [0, 1] is e - 1
+	integral, err := c.GaussLegendre(func(x *Float) *Float {
+		return newFloat(x.A.Prec()).Exp(x)
+	}, big.NewFloat(0), big.NewFloat(1))
+	e := newFloat(256).Exp(newReal(1, 256))
+	if !quadratureCheck(integral, err, e.Sub(e, newReal(1, 256)), 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+
+	// the integral of e^(ix) over [0, pi] is 2i
+	integral, err = c.GaussLegendre(func(x *Float) *Float {
+		y := NewFloat(big.NewFloat(0).SetPrec(x.A.Prec()), x.A)
+		return y.Exp(y)
+	}, big.NewFloat(0), bigfloat.PI(256))
+	if !quadratureCheck(integral, err, NewFloat(big.NewFloat(0), big.NewFloat(2)), 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+}
+
+func TestContour(t *testing.T) {
+	// the integral of z^2 along the segment from 0 to 1 + i is (1 + i)^3/3 = (-2 + 2i)/3
+	c := NewContext(128, big.ToNearestEven)
+	integral, err := Contour(c.TanhSinh, func(z *Float) *Float {
+		return newFloat(z.A.Prec()).Mul(z, z)
+	}, func(t *Float) *Float {
+		return NewFloat(big.NewFloat(0).SetPrec(t.A.Prec()).Set(t.A), big.NewFloat(0).SetPrec(t.A.Prec()).Set(t.A))
+	}, func(t *Float) *Float {
+		return NewFloat(big.NewFloat(1), big.NewFloat(1))
+	}, big.NewFloat(0), big.NewFloat(1))
+	exact := newFloat(256)
+	exact.SetRat(NewRational(big.NewRat(-2, 3), big.NewRat(2, 3)))
+	if !quadratureCheck(integral, err, exact, 100) {
+		t.Fatal("invalid integral", integral.String(), err.String())
+	}
+}
+
+func TestContext_Residue(t *testing.T) {
+	c := NewContext(128, big.ToNearestEven)
+
+	// the residue of e^z/z^3 at 0 is 1/2
+	residue, err := c.Residue(func(z *Float) *Float {
+		y := newFloat(z.A.Prec()).Mul(z, z)
+		y.Mul(y, z)
+		return y.Div(newFloat(z.A.Prec()).Exp(z), y)
+	}, newFloat(128), big.NewFloat(1))
+	if !quadratureCheck(residue, err, NewFloat(big.NewFloat(.5), big.NewFloat(0)), 100) {
+		t.Fatal("invalid residue", residue.String(), err.String())
+	}
+
+	// the residues of 1/(z^2 + 1) are -i/2 at i and i/2 at -i
+	f := func(z *Float) *Float {
+		y := newFloat(z.A.Prec()).Mul(z, z)
+		y.Add(y, newReal(1, z.A.Prec()))
+		return y.Div(newReal(1, z.A.Prec()), y)
+	}
+	center := NewFloat(big.NewFloat(0), big.NewFloat(1))
+	residue, err = c.Residue(f, center, big.NewFloat(.5))
+	if !quadratureCheck(residue, err, NewFloat(big.NewFloat(0), big.NewFloat(-.5)), 100) {
+		t.Fatal("invalid residue", residue.String(), err.String())
+	}
+	residue, err = c.Residue(f, newFloat(128), big.NewFloat(3))
+	if !quadratureCheck(residue, err, newFloat(128), 100) {
+		t.Fatal("invalid residue", residue.String(), err.String())
+	}
+}
+
+func TestContext_CountZeros(t *testing.T) {
+	c := NewContext(64, big.ToNearestEven)
+	// z^3 - 1 has the zeros 1 and (-1 +/- sqrt(3)i)/2
+	f := func(z *Float) *Float {
+		y := newFloat(z.A.Prec()).Mul(z, z)
+		y.Mul(y, z)
+		return y.Sub(y, newReal(1, z.A.Prec()))
+	}
+	df := func(z *Float) *Float {
+		y := newFloat(z.A.Prec()).Mul(z, z)
+		return y.Mul(y, newReal(3, z.A.Prec()))
+	}
+	for _, test := range []struct {
+		center *Float
+		radius float64
+		n      int
+	}{
+		{newFloat(64), 2, 3},
+		{newFloat(64), .5, 0},
+		{newReal(1, 64), .5, 1},
+		{NewFloat(big.NewFloat(-.5), big.NewFloat(0)), 1, 2},
+	} {
+		if n, ok := c.CountZeros(f, df, test.center, big.NewFloat(test.radius)); !ok || n != test.n {
+			t.Fatal("invalid number of zeros", test.center.String(), test.radius, n, ok)
+		}
+	}
+
+	// 1/z has a pole and no zeros
+	n, ok := c.CountZeros(func(z *Float) *Float {
+		return newFloat(z.A.Prec()).Div(newReal(1, z.A.Prec()), z)
+	}, func(z *Float) *Float {
+		y := newFloat(z.A.Prec()).Mul(z, z)
+		return y.Div(newReal(-1, z.A.Prec()), y)
+	}, newFloat(64), big.NewFloat(1))
+	if !ok || n != -1 {
+		t.Fatal("invalid number of zeros", n, ok)
+	}
+}
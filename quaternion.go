@@ -0,0 +1,340 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// Quaternion is a quaternion a + bi + cj + dk, the result of a method is rounded to the
+// precision and mode of the receiver, which may alias the operands, which are never changed
+// https://en.wikipedia.org/wiki/Quaternion
+type Quaternion struct {
+	A, B, C, D *big.Float
+}
+
+// NewQuaternion creates a new quaternion
+func NewQuaternion(a, b, c, d *big.Float) *Quaternion {
+	return &Quaternion{
+		A: a,
+		B: b,
+		C: c,
+		D: d,
+	}
+}
+
+// newQuaternion creates a zero quaternion with the given precision
+func newQuaternion(prec uint) *Quaternion {
+	return NewQuaternion(big.NewFloat(0).SetPrec(prec), big.NewFloat(0).SetPrec(prec),
+		big.NewFloat(0).SetPrec(prec), big.NewFloat(0).SetPrec(prec))
+}
+
+// parts returns the components of q
+func (q *Quaternion) parts() [4]*big.Float {
+	return [4]*big.Float{q.A, q.B, q.C, q.D}
+}
+
+// clone copies q into a new quaternion with the given precision
+func (q *Quaternion) clone(prec uint) *Quaternion {
+	y := newQuaternion(prec)
+	y.set(q)
+	return y
+}
+
+// Set sets q to x rounded to the precision of q
+func (q *Quaternion) Set(x *Quaternion) *Quaternion {
+	return q.set(x)
+}
+
+// set rounds the components of x into q
+func (q *Quaternion) set(x *Quaternion) *Quaternion {
+	p, y := q.parts(), x.parts()
+	for i := range p {
+		p[i].Set(y[i])
+	}
+	return q
+}
+
+// prec returns the working precision of q
+func (q *Quaternion) prec() uint {
+	return q.A.Prec() + floatGuard
+}
+
+// Add adds two quaternions
+func (q *Quaternion) Add(a, b *Quaternion) *Quaternion {
+	q.A.Add(a.A, b.A)
+	q.B.Add(a.B, b.B)
+	q.C.Add(a.C, b.C)
+	q.D.Add(a.D, b.D)
+	return q
+}
+
+// Sub subtracts two quaternions
+func (q *Quaternion) Sub(a, b *Quaternion) *Quaternion {
+	q.A.Sub(a.A, b.A)
+	q.B.Sub(a.B, b.B)
+	q.C.Sub(a.C, b.C)
+	q.D.Sub(a.D, b.D)
+	return q
+}
+
+// Neg negates a quaternion
+func (q *Quaternion) Neg(a *Quaternion) *Quaternion {
+	q.A.Neg(a.A)
+	q.B.Neg(a.B)
+	q.C.Neg(a.C)
+	q.D.Neg(a.D)
+	return q
+}
+
+// Conj computes the conjugate a - bi - cj - dk of a
+func (q *Quaternion) Conj(a *Quaternion) *Quaternion {
+	q.A.Set(a.A)
+	q.B.Neg(a.B)
+	q.C.Neg(a.C)
+	q.D.Neg(a.D)
+	return q
+}
+
+// mul computes the Hamilton product of a and b with the given precision
+func mul(a, b *Quaternion, prec uint) *Quaternion {
+	y := newQuaternion(prec)
+	x, z := a.parts(), b.parts()
+	// the sign and component of the product of the basis elements e_i e_j
+	table := [4][4]struct {
+		sign      int
+		component int
+	}{
+		{{1, 0}, {1, 1}, {1, 2}, {1, 3}},
+		{{1, 1}, {-1, 0}, {1, 3}, {-1, 2}},
+		{{1, 2}, {-1, 3}, {-1, 0}, {1, 1}},
+		{{1, 3}, {1, 2}, {-1, 1}, {-1, 0}},
+	}
+	p := y.parts()
+	for i := range x {
+		for j := range z {
+			e := table[i][j]
+			t := big.NewFloat(0).SetPrec(prec).Mul(x[i], z[j])
+			if e.sign < 0 {
+				p[e.component].Sub(p[e.component], t)
+			} else {
+				p[e.component].Add(p[e.component], t)
+			}
+		}
+	}
+	return y
+}
+
+// Mul computes the Hamilton product of two quaternions
+// https://en.wikipedia.org/wiki/Quaternion#Hamilton_product
+func (q *Quaternion) Mul(a, b *Quaternion) *Quaternion {
+	return q.set(mul(a, b, q.prec()))
+}
+
+// norm2 computes a^2 + b^2 + c^2 + d^2 with the given precision
+func (q *Quaternion) norm2(prec uint) *big.Float {
+	n := big.NewFloat(0).SetPrec(prec)
+	for _, x := range q.parts() {
+		n.Add(n, big.NewFloat(0).SetPrec(prec).Mul(x, x))
+	}
+	return n
+}
+
+// Norm computes the norm |q| = sqrt(a^2 + b^2 + c^2 + d^2)
+// https://en.wikipedia.org/wiki/Quaternion#Conjugation,_the_norm,_and_reciprocal
+func (q *Quaternion) Norm() *big.Float {
+	prec := q.prec()
+	n := q.norm2(prec)
+	return big.NewFloat(0).SetPrec(q.A.Prec()).Sqrt(n)
+}
+
+// inv computes 1/a = conj(a)/|a|^2 with the given precision
+func inv(a *Quaternion, prec uint) *Quaternion {
+	n := a.norm2(prec)
+	y := a.clone(prec)
+	y.Conj(y)
+	for _, x := range y.parts() {
+		x.Quo(x, n)
+	}
+	return y
+}
+
+// Inv computes the reciprocal 1/a, which is infinite for a zero a
+func (q *Quaternion) Inv(a *Quaternion) *Quaternion {
+	if a.norm2(q.prec()).Sign() == 0 {
+		q.A.SetInf(false)
+		q.B.SetInt64(0)
+		q.C.SetInt64(0)
+		q.D.SetInt64(0)
+		return q
+	}
+	return q.set(inv(a, q.prec()))
+}
+
+// analytic computes the analytic function of a quaternion a + v with the vector part v,
+// f(a + v) = Re f(z) + Im f(z) v/|v| for the complex number z = a + |v|i
+// https://en.wikipedia.org/wiki/Quaternion#Functions_of_a_quaternion_variable
+func (q *Quaternion) analytic(x *Quaternion, function func(f, z *Float) *Float) *Quaternion {
+	prec := q.prec()
+	v := x.clone(prec)
+	v.A.SetInt64(0)
+	r := big.NewFloat(0).SetPrec(prec).Sqrt(v.norm2(prec))
+	z := NewFloat(big.NewFloat(0).SetPrec(prec).Set(x.A), r)
+	f := function(newFloat(prec), z)
+	y := newQuaternion(prec)
+	y.A.Set(f.A)
+	if r.Sign() == 0 {
+		// the direction of the imaginary part of a real quaternion is i
+		y.B.Set(f.B)
+		return q.set(y)
+	}
+	vp, yp := v.parts(), y.parts()
+	for i := 1; i < len(yp); i++ {
+		yp[i].Mul(f.B, vp[i])
+		yp[i].Quo(yp[i], r)
+	}
+	return q.set(y)
+}
+
+// Exp computes e^x = e^a (cos|v| + v/|v| sin|v|)
+// https://en.wikipedia.org/wiki/Quaternion#Exponential,_logarithm,_and_power_functions
+func (q *Quaternion) Exp(x *Quaternion) *Quaternion {
+	return q.analytic(x, (*Float).Exp)
+}
+
+// Log computes the natural log of x = log|x| + v/|v| acos(a/|x|)
+// https://en.wikipedia.org/wiki/Quaternion#Exponential,_logarithm,_and_power_functions
+func (q *Quaternion) Log(x *Quaternion) *Quaternion {
+	return q.analytic(x, (*Float).Log)
+}
+
+// Sqrt computes the principal square root of x
+func (q *Quaternion) Sqrt(x *Quaternion) *Quaternion {
+	return q.analytic(x, (*Float).Sqrt)
+}
+
+// Pow computes x**y = e^(y log(x)) for a real exponent y
+// https://en.wikipedia.org/wiki/Quaternion#Exponential,_logarithm,_and_power_functions
+func (q *Quaternion) Pow(x *Quaternion, y *big.Float) *Quaternion {
+	return q.analytic(x, func(f, z *Float) *Float {
+		return f.Pow(z, NewFloat(big.NewFloat(0).SetPrec(f.A.Prec()).Set(y), big.NewFloat(0)))
+	})
+}
+
+// Slerp computes the spherical linear interpolation a (a^-1 b)^t of the unit quaternions
+// a and b, along the shorter arc
+// https://en.wikipedia.org/wiki/Slerp#Quaternion_Slerp
+func (q *Quaternion) Slerp(a, b *Quaternion, t *big.Float) *Quaternion {
+	prec := q.prec()
+	d := mul(inv(a, prec), b, prec)
+	if d.A.Sign() < 0 {
+		// q and -q are the same rotation
+		d.Neg(d)
+	}
+	d.Pow(d, t)
+	return q.set(mul(a, d, prec))
+}
+
+// Matrix computes the rotation matrix of the unit quaternion q/|q|, which is the identity
+// for q = 0
+// https://en.wikipedia.org/wiki/Quaternions_and_spatial_rotation#Quaternion-derived_rotation_matrix
+func (q *Quaternion) Matrix() Matrix {
+	prec := q.prec()
+	n := q.norm2(prec)
+	p := q.parts()
+	// s = 2/|q|^2, or 0 for q = 0
+	s := big.NewFloat(0).SetPrec(prec)
+	if n.Sign() != 0 {
+		s.Quo(big.NewFloat(2), n)
+	}
+	product := func(i, j int) *big.Float {
+		x := big.NewFloat(0).SetPrec(prec).Mul(p[i], p[j])
+		return x.Mul(x, s)
+	}
+	sum := func(x, y *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(prec).Add(x, y)
+	}
+	difference := func(x, y *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(prec).Sub(x, y)
+	}
+	one := big.NewFloat(1).SetPrec(prec)
+	values := [3][3]*big.Float{
+		{difference(one, sum(product(2, 2), product(3, 3))), difference(product(1, 2), product(3, 0)), sum(product(1, 3), product(2, 0))},
+		{sum(product(1, 2), product(3, 0)), difference(one, sum(product(1, 1), product(3, 3))), difference(product(2, 3), product(1, 0))},
+		{difference(product(1, 3), product(2, 0)), sum(product(2, 3), product(1, 0)), difference(one, sum(product(1, 1), product(2, 2)))},
+	}
+
+	m := NewMatrix(q.A.Prec())
+	m.Mode = q.A.Mode()
+	for _, v := range values {
+		var row []Rational
+		for _, x := range v {
+			r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+			m.Context().float(x).Rat(r.A)
+			row = append(row, *r)
+		}
+		m.Values = append(m.Values, row)
+	}
+	return m
+}
+
+// SetMatrix sets q to the unit quaternion with a >= 0 of the real parts of the 3x3 rotation matrix m
+// https://en.wikipedia.org/wiki/Rotation_matrix#Quaternion
+func (q *Quaternion) SetMatrix(m *Matrix) *Quaternion {
+	prec := q.prec()
+	var r [3][3]*big.Float
+	for i := range r {
+		for j := range r[i] {
+			r[i][j] = big.NewFloat(0).SetPrec(prec).SetRat(m.Values[i][j].A)
+		}
+	}
+	sum := func(x ...*big.Float) *big.Float {
+		y := big.NewFloat(0).SetPrec(prec)
+		for _, x := range x {
+			y.Add(y, x)
+		}
+		return y
+	}
+	neg := func(x *big.Float) *big.Float {
+		return big.NewFloat(0).SetPrec(prec).Neg(x)
+	}
+	one := big.NewFloat(1).SetPrec(prec)
+
+	// the largest of 4a^2, 4b^2, 4c^2 and 4d^2 is computed first for stability
+	squares := [4]*big.Float{
+		sum(one, r[0][0], r[1][1], r[2][2]),
+		sum(one, r[0][0], neg(r[1][1]), neg(r[2][2])),
+		sum(one, neg(r[0][0]), r[1][1], neg(r[2][2])),
+		sum(one, neg(r[0][0]), neg(r[1][1]), r[2][2]),
+	}
+	k := 0
+	for i := range squares {
+		if squares[i].Cmp(squares[k]) > 0 {
+			k = i
+		}
+	}
+	// the off diagonal sums and differences are 4 times the products of the components
+	products := [4][4]*big.Float{
+		{squares[0], sum(r[2][1], neg(r[1][2])), sum(r[0][2], neg(r[2][0])), sum(r[1][0], neg(r[0][1]))},
+		{sum(r[2][1], neg(r[1][2])), squares[1], sum(r[0][1], r[1][0]), sum(r[0][2], r[2][0])},
+		{sum(r[0][2], neg(r[2][0])), sum(r[0][1], r[1][0]), squares[2], sum(r[1][2], r[2][1])},
+		{sum(r[1][0], neg(r[0][1])), sum(r[0][2], r[2][0]), sum(r[1][2], r[2][1]), squares[3]},
+	}
+	s := big.NewFloat(0).SetPrec(prec).Sqrt(squares[k])
+	s.Add(s, s)
+	y := newQuaternion(prec)
+	for i, p := range y.parts() {
+		p.Quo(products[k][i], s)
+	}
+	if y.A.Sign() < 0 {
+		y.Neg(y)
+	}
+	return q.set(y)
+}
+
+// String returns a string of the quaternion
+func (q *Quaternion) String() string {
+	return q.A.String() + " + " + q.B.String() + "i + " + q.C.String() + "j + " + q.D.String() + "k"
+}
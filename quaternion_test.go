@@ -0,0 +1,153 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// quaternion creates a quaternion with the given precision
+func quaternion(prec uint, a, b, c, d float64) *Quaternion {
+	return NewQuaternion(big.NewFloat(a).SetPrec(prec), big.NewFloat(b).SetPrec(prec),
+		big.NewFloat(c).SetPrec(prec), big.NewFloat(d).SetPrec(prec))
+}
+
+// quaternionClose determines if a and b differ by less than 2^-e in every component
+func quaternionClose(a, b *Quaternion, e int) bool {
+	d := newQuaternion(a.A.Prec()).Sub(a, b)
+	for _, x := range d.parts() {
+		if x.Sign() != 0 && x.MantExp(nil) > -e {
+			return false
+		}
+	}
+	return true
+}
+
+func TestQuaternion_Mul(t *testing.T) {
+	i, j, k := quaternion(64, 0, 1, 0, 0), quaternion(64, 0, 0, 1, 0), quaternion(64, 0, 0, 0, 1)
+	if s := newQuaternion(64).Mul(i, j).String(); s != "0 + 0i + 0j + 1k" {
+		t.Fatal("invalid product", s)
+	}
+	if s := newQuaternion(64).Mul(j, i).String(); s != "0 + 0i + 0j + -1k" {
+		t.Fatal("invalid product", s)
+	}
+	ijk := newQuaternion(64).Mul(i, j)
+	ijk.Mul(ijk, k)
+	if s := ijk.String(); s != "-1 + 0i + 0j + 0k" {
+		t.Fatal("invalid product", s)
+	}
+
+	a, b := quaternion(64, 1, 2, 3, 4), quaternion(64, -2, .5, 1, 3)
+	if s := newQuaternion(64).Mul(a, b).String(); s != "-18 + 1.5i + -9j + -4.5k" {
+		t.Fatal("invalid product", s)
+	}
+	if s := a.Norm().String(); s != "5.477225575" {
+		t.Fatal("invalid norm", s)
+	}
+	x := newQuaternion(64).Inv(a)
+	x.Mul(x, a)
+	if !quaternionClose(x, quaternion(64, 1, 0, 0, 0), 62) {
+		t.Fatal("invalid inverse", x.String())
+	}
+}
+
+func TestQuaternion_ExpLog(t *testing.T) {
+	a := quaternion(128, .5, -1, 2, .25)
+	x := newQuaternion(128).Log(a)
+	x.Exp(x)
+	if !quaternionClose(x, a, 124) {
+		t.Fatal("invalid exp(log(a))", x.String())
+	}
+
+	// e^(pi/2 k) = k
+	e := quaternion(128, 0, 0, 0, math.Pi/2)
+	e.Exp(e)
+	if !quaternionClose(e, quaternion(128, 0, 0, 0, 1), 50) {
+		t.Fatal("invalid exp", e.String())
+	}
+
+	// log(-1) = pi i
+	l := newQuaternion(64).Log(quaternion(64, -1, 0, 0, 0))
+	if s := l.String(); s != "0 + 3.141592654i + 0j + 0k" {
+		t.Fatal("invalid log", s)
+	}
+
+	p := newQuaternion(128).Pow(a, big.NewFloat(3))
+	q := newQuaternion(128).Mul(a, a)
+	q.Mul(q, a)
+	if !quaternionClose(p, q, 118) {
+		t.Fatal("invalid power", p.String(), q.String())
+	}
+	s := newQuaternion(128).Sqrt(a)
+	s.Mul(s, s)
+	if !quaternionClose(s, a, 124) {
+		t.Fatal("invalid square root", s.String())
+	}
+}
+
+func TestQuaternion_Slerp(t *testing.T) {
+	// halfway between the identity and a rotation of pi/2 about z is a rotation of pi/4 about z
+	a := quaternion(128, 1, 0, 0, 0)
+	b := quaternion(128, math.Sqrt(.5), 0, 0, math.Sqrt(.5))
+	s := newQuaternion(128).Slerp(a, b, big.NewFloat(.5))
+	expected := quaternion(128, math.Cos(math.Pi/8), 0, 0, math.Sin(math.Pi/8))
+	if !quaternionClose(s, expected, 50) {
+		t.Fatal("invalid slerp", s.String())
+	}
+	// -b is the same rotation
+	s.Slerp(a, newQuaternion(128).Neg(b), big.NewFloat(.5))
+	if !quaternionClose(s, expected, 50) {
+		t.Fatal("invalid slerp", s.String())
+	}
+}
+
+func TestQuaternion_Matrix(t *testing.T) {
+	a := quaternion(128, 1, 2, 3, 4)
+	b := quaternion(128, -2, .5, 1, 3)
+	ma, mb := a.Matrix(), b.Matrix()
+
+	// the rotation of a composition is the product of the rotations
+	ab := newQuaternion(128).Mul(a, b)
+	mab := ab.Matrix()
+	m := NewMatrix(128)
+	m.Mul(&ma, &mb)
+	m.Sub(&m, &mab)
+	for _, row := range m.Values {
+		for _, x := range row {
+			f := newFloat(128)
+			f.SetRat(&x)
+			if f.A.Sign() != 0 && f.A.MantExp(nil) > -120 {
+				t.Fatal("invalid rotation", m.String())
+			}
+		}
+	}
+
+	n := newQuaternion(128).SetMatrix(&ma)
+	unit := newQuaternion(128).Set(a)
+	norm := a.Norm()
+	for _, x := range unit.parts() {
+		x.Quo(x, norm)
+	}
+	if !quaternionClose(n, unit, 124) {
+		t.Fatal("invalid quaternion", n.String(), unit.String())
+	}
+	n.SetMatrix(&mb)
+	unit.Set(b)
+	norm = b.Norm()
+	for _, x := range unit.parts() {
+		x.Quo(x, norm)
+		x.Neg(x)
+	}
+	if !quaternionClose(n, unit, 124) {
+		t.Fatal("invalid quaternion", n.String(), unit.String())
+	}
+
+	zero := quaternion(128, 0, 0, 0, 0).Matrix()
+	if zero.String() != "[1 0 0;0 1 0;0 0 1]" {
+		t.Fatal("invalid rotation", zero.String())
+	}
+}
@@ -0,0 +1,209 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"sort"
+)
+
+// rootsGuard is the number of guard bits used by the root finder
+const rootsGuard = 32
+
+// rootsStart is the precision of the first stage of the root finder
+const rootsStart = 64
+
+// NewPolynomial creates a new polynomial over the Float numbers with the coefficients of p
+// rounded to the context
+func (c *Context) NewPolynomial(p *Polynomial[*Rational]) Polynomial[*Float] {
+	field := FloatField{Context: c}
+	values := make([]*Float, len(p.Coefficients))
+	for i := range values {
+		values[i] = field.Zero()
+		values[i].SetRat(p.Coefficients[i])
+	}
+	return NewPolynomial[*Float](field, values...)
+}
+
+// log2 computes an approximation of log2(|x|) for a non zero x
+func log2(x *big.Float) float64 {
+	e := x.MantExp(nil)
+	f, _ := big.NewFloat(0).SetMantExp(x, -e).Float64()
+	return float64(e) + math.Log2(math.Abs(f))
+}
+
+// rootsBound computes log2 of the Fujiwara bound 2 max(|a_(n-k)/a_n|^(1/k), |a_0/(2 a_n)|^(1/n))
+// or of the Cauchy bound 1 + max |a_k/a_n| of the absolute value of the roots of the polynomial
+// with the coefficients, whichever is smaller
+// https://en.wikipedia.org/wiki/Geometrical_properties_of_polynomial_roots#Bounds_on_all_roots
+func rootsBound(coefficients []*Float) float64 {
+	n := len(coefficients) - 1
+	magnitude := func(x *Float) float64 {
+		if x.isZero() {
+			return math.Inf(-1)
+		}
+		return log2(modulus(x, 64))
+	}
+	lead := magnitude(coefficients[n])
+	fujiwara, cauchy := math.Inf(-1), math.Inf(-1)
+	for k := 1; k <= n; k++ {
+		a := magnitude(coefficients[n-k]) - lead
+		cauchy = math.Max(cauchy, a)
+		if k == n {
+			// only the Fujiwara bound halves the constant term
+			a--
+		}
+		fujiwara = math.Max(fujiwara, a/float64(k))
+	}
+	cauchy = math.Log2(1 + math.Exp2(cauchy))
+	return math.Min(fujiwara+1, cauchy)
+}
+
+// rootsEval computes p(x) and p'(x) with Horner's method and the precision of x
+func rootsEval(coefficients []*Float, x *Float) (y, d *Float) {
+	prec := x.A.Prec()
+	y, d = newFloat(prec), newFloat(prec)
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		d.Mul(d, x)
+		d.Add(d, y)
+		y.Mul(y, x)
+		y.Add(y, coefficients[i])
+	}
+	return y, d
+}
+
+// aberth improves the approximations z of the roots of the polynomial with the coefficients
+// until the corrections are 8 bits below the precision of z relative to the roots, or the
+// number of iterations is exceeded
+func aberth(coefficients []*Float, z []*Float, iterations int) {
+	prec := z[0].A.Prec()
+	done := make([]bool, len(z))
+	eps := big.NewFloat(0).SetMantExp(big.NewFloat(1), 8-int(prec))
+	for k := 0; k < iterations; k++ {
+		converged := true
+		for i := range z {
+			if done[i] {
+				continue
+			}
+			y, d := rootsEval(coefficients, z[i])
+			if y.isZero() {
+				done[i] = true
+				continue
+			}
+			// s = sum 1/(z_i - z_j)
+			s, t := newFloat(prec), newFloat(prec)
+			for j := range z {
+				if j != i {
+					t.Sub(z[i], z[j])
+					s.Add(s, t.inv(t))
+				}
+			}
+			// w = (y/d)/(1 - (y/d) s) = y/(d - y s)
+			w := newFloat(prec)
+			t.Mul(y, s)
+			t.Sub(d, t)
+			if t.isZero() {
+				converged = false
+				continue
+			}
+			w.Mul(y, t.inv(t))
+			z[i].Sub(z[i], w)
+			e := big.NewFloat(0).Mul(norm(z[i]), eps)
+			if norm(w).Cmp(e.Mul(e, eps)) <= 0 {
+				done[i] = true
+			} else {
+				converged = false
+			}
+		}
+		if converged {
+			return
+		}
+	}
+}
+
+// rootsRadius computes the radius n|W_i| of the Weierstrass correction
+// W_i = p(z_i)/(a_n prod_(j != i) (z_i - z_j)) with ball arithmetic, the union of the disks
+// contains the roots and a connected component of m disks contains m roots
+// https://doi.org/10.1007/BF02243806
+func rootsRadius(coefficients []*Float, z []*Float, i int, prec uint) *big.Float {
+	n := len(coefficients) - 1
+	x := exactBall(z[i])
+	y, q := newBall(prec), exactBall(coefficients[n])
+	for k := n; k >= 0; k-- {
+		y.Mul(y, x)
+		y.Add(y, exactBall(coefficients[k]))
+	}
+	t := newBall(prec)
+	for j := range z {
+		if j != i {
+			q.Mul(q, t.Sub(x, exactBall(z[j])))
+		}
+	}
+	y.Div(y, q)
+	r := y.mag()
+	return r.Mul(r, newRad().SetInt64(int64(n)))
+}
+
+// Roots computes the roots of a polynomial of degree n > 0 with the Aberth-Ehrlich method,
+// starting from points on a circle between the bounds of the roots and doubling the
+// precision until prec. Each root is returned as a ball with a certified radius: the union of
+// the balls contains every root, and a ball that doesn't intersect the other balls contains
+// exactly one root. The roots are sorted by their real parts and then their imaginary parts
+// https://en.wikipedia.org/wiki/Aberth_method
+func Roots(p *Polynomial[*Float], prec uint) []*Ball {
+	if p.Degree() < 1 {
+		panic("polynomial of degree 0")
+	}
+	var roots []*Ball
+	coefficients := p.Coefficients
+	for coefficients[0].isZero() {
+		roots = append(roots, newBall(prec))
+		coefficients = coefficients[1:]
+	}
+	n := len(coefficients) - 1
+	if n > 0 {
+		// the radius of the circle is the geometric mean of the bounds of p(x) and x^n p(1/x)
+		reversed := make([]*Float, n+1)
+		for i := range reversed {
+			reversed[i] = coefficients[n-i]
+		}
+		r := (rootsBound(coefficients) - rootsBound(reversed)) / 2
+		z := make([]*Float, n)
+		for k := range z {
+			angle := 2*math.Pi*float64(k)/float64(n) + .7
+			z[k] = newFloat(rootsStart)
+			z[k].A.SetMantExp(big.NewFloat(math.Cos(angle)), int(math.Round(r)))
+			z[k].B.SetMantExp(big.NewFloat(math.Sin(angle)), int(math.Round(r)))
+		}
+
+		wp, iterations := prec+rootsGuard, 64+4*n
+		for stage := uint(rootsStart); ; stage *= 2 {
+			if stage > wp {
+				stage = wp
+			}
+			for k := range z {
+				z[k] = z[k].clone(stage)
+			}
+			aberth(coefficients, z, iterations)
+			if stage == wp {
+				break
+			}
+			iterations = 16
+		}
+		for i := range z {
+			b := newBall(prec)
+			roots = append(roots, b.set(z[i], rootsRadius(coefficients, z, i, wp)))
+		}
+	}
+	sort.Slice(roots, func(i, j int) bool {
+		a, b := roots[i].M, roots[j].M
+		if c := a.A.Cmp(b.A); c != 0 {
+			return c < 0
+		}
+		return a.B.Cmp(b.B) < 0
+	})
+	return roots
+}
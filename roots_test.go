@@ -0,0 +1,126 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// rootsDisjoint determines if the balls don't intersect
+func rootsDisjoint(roots []*Ball) bool {
+	for i := range roots {
+		for j := i + 1; j < len(roots); j++ {
+			d := newFloat(roots[i].prec()).Sub(roots[i].M, roots[j].M)
+			r := newRad().Add(roots[i].R, roots[j].R)
+			if radMagLower(d).Cmp(r) <= 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+func TestRoots(t *testing.T) {
+	// (x - 1)(x - 2) ... (x - 20)
+	field := NewFloatField(256, big.ToNearestEven)
+	p := NewPolynomial[*Float](field, field.One())
+	for k := int64(1); k <= 20; k++ {
+		q := NewPolynomial[*Float](field, newReal(-k, 256), field.One())
+		p.Mul(&p, &q)
+	}
+	roots := Roots(&p, 256)
+	if len(roots) != 20 || !rootsDisjoint(roots) {
+		t.Fatal("invalid roots", len(roots))
+	}
+	for k, root := range roots {
+		if !root.Contains(newReal(int64(k+1), 256)) || root.R.Cmp(big.NewFloat(0x1p-200)) > 0 {
+			t.Fatal("invalid root", k+1, root.String())
+		}
+	}
+}
+
+func TestRootsBound(t *testing.T) {
+	field := NewFloatField(64, big.ToNearestEven)
+	c := func(x float64) *Float {
+		return field.Context.NewFloat(big.NewFloat(x), nil)
+	}
+	type Test struct {
+		coefficients []*Float
+		root         float64
+	}
+	tests := []Test{
+		{[]*Float{c(-10), c(1)}, 10},
+		{[]*Float{c(2), c(-3), c(1)}, 2},
+		{[]*Float{c(-0.25), c(0), c(1)}, 0.5},
+		{[]*Float{c(-1000), c(0), c(0), c(1)}, 10},
+	}
+	for _, test := range tests {
+		if bound := math.Exp2(rootsBound(test.coefficients)); bound < test.root*(1-1e-12) {
+			t.Fatal("invalid bound", bound, test.root)
+		}
+	}
+}
+
+func TestRoots_Unity(t *testing.T) {
+	// x^100 - 2 at 512 bits
+	const n, prec = 100, 512
+	field := NewFloatField(prec, big.ToNearestEven)
+	coefficients := make([]*Float, n+1)
+	for i := range coefficients {
+		coefficients[i] = field.Zero()
+	}
+	coefficients[0].A.SetInt64(-2)
+	coefficients[n].A.SetInt64(1)
+	p := NewPolynomial[*Float](field, coefficients...)
+	roots := Roots(&p, prec)
+	if len(roots) != n || !rootsDisjoint(roots) {
+		t.Fatal("invalid roots", len(roots))
+	}
+	sum := newFloat(prec)
+	for _, root := range roots {
+		if root.R.Cmp(big.NewFloat(0x1p-480)) > 0 {
+			t.Fatal("invalid radius", root.String())
+		}
+		sum.Add(sum, root.M)
+	}
+	// the sum of the roots is 0
+	if norm(sum).Cmp(big.NewFloat(0x1p-960)) > 0 {
+		t.Fatal("invalid sum", sum.String())
+	}
+	if s := roots[n-1].M.A.String(); s != "1.00695555" {
+		t.Fatal("invalid root", s)
+	}
+}
+
+func TestRoots_Multiple(t *testing.T) {
+	// x^2 (x - 1)^2 (x - i)
+	c := NewContext(128, big.ToNearestEven)
+	a, b := rationalPolynomial(-1, 0, 1, 0), rationalPolynomial(0, -1, 1, 0)
+	p := rationalPolynomial(0, 0, 0, 0, 1, 0)
+	p.Mul(&p, &a)
+	p.Mul(&p, &a)
+	p.Mul(&p, &b)
+	q := c.NewPolynomial(&p)
+	roots := Roots(&q, 128)
+	if len(roots) != 5 {
+		t.Fatal("invalid roots", len(roots))
+	}
+	counts := make(map[string]int)
+	for _, root := range roots {
+		switch {
+		case root.M.isZero() && root.R.Sign() == 0:
+			counts["0"]++
+		case root.Contains(c.NewFloat(nil, big.NewFloat(1))):
+			counts["i"]++
+		case root.Contains(c.NewFloat(big.NewFloat(1), nil)) && root.R.Cmp(big.NewFloat(0x1p-50)) < 0:
+			counts["1"]++
+		}
+	}
+	if counts["0"] != 2 || counts["i"] != 1 || counts["1"] != 2 {
+		t.Fatal("invalid roots", counts)
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// rref reduces a to the reduced row echelon form with Gauss-Jordan elimination and returns
+// the pivot columns, a is changed
+// https://en.wikipedia.org/wiki/Row_echelon_form#Reduced_row_echelon_form
+func rref[T any](field Field[T], a [][]T) []int {
+	normed, isNormed := field.(Normed[T])
+	var pivots []int
+	if len(a) == 0 {
+		return pivots
+	}
+	row := 0
+	for column := 0; column < len(a[0]) && row < len(a); column++ {
+		pivot := -1
+		var max *big.Float
+		for j := row; j < len(a); j++ {
+			if field.IsZero(a[j][column]) {
+				continue
+			}
+			if !isNormed {
+				pivot = j
+				break
+			}
+			if m := normed.Magnitude(a[j][column]); max == nil || m.Cmp(max) > 0 {
+				pivot, max = j, m
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		a[row], a[pivot] = a[pivot], a[row]
+		inv := field.Inv(a[row][column])
+		for k := range a[row] {
+			a[row][k] = field.Mul(a[row][k], inv)
+		}
+		// the pivot is exactly 1 for inexact fields
+		a[row][column] = field.One()
+		for j := range a {
+			if j == row || field.IsZero(a[j][column]) {
+				continue
+			}
+			factor := field.Neg(a[j][column])
+			for k := range a[j] {
+				a[j][k] = field.Add(a[j][k], field.Mul(factor, a[row][k]))
+			}
+			a[j][column] = field.Zero()
+		}
+		pivots = append(pivots, column)
+		row++
+	}
+	return pivots
+}
+
+// RREF sets d to the reduced row echelon form of a and returns d and the pivot columns
+func (d *Dense[T]) RREF(a *Dense[T]) (*Dense[T], []int) {
+	values := a.copy()
+	pivots := rref(a.Field, values)
+	d.Field, d.Values = a.Field, values
+	return d, pivots
+}
+
+// Rank computes the rank of d, the number of pivot columns
+// https://en.wikipedia.org/wiki/Rank_(linear_algebra)
+func (d *Dense[T]) Rank() int {
+	return len(rref(d.Field, d.copy()))
+}
+
+// columns returns the number of columns of d
+func (d *Dense[T]) columns() int {
+	if len(d.Values) == 0 {
+		return 0
+	}
+	return len(d.Values[0])
+}
+
+// NullSpace sets d to the matrix with columns that are a basis of the null space of a,
+// the vectors x with ax = 0, there is one column for each column of a that isn't a pivot
+// https://en.wikipedia.org/wiki/Kernel_(linear_algebra)
+func (d *Dense[T]) NullSpace(a *Dense[T]) *Dense[T] {
+	field, n := a.Field, a.columns()
+	r := a.copy()
+	pivots := rref(field, r)
+	isPivot := make([]bool, n)
+	for _, p := range pivots {
+		isPivot[p] = true
+	}
+	values := make([][]T, n)
+	for free := 0; free < n; free++ {
+		if isPivot[free] {
+			continue
+		}
+		for i := range values {
+			values[i] = append(values[i], field.Zero())
+		}
+		last := len(values[free]) - 1
+		values[free][last] = field.One()
+		for i, p := range pivots {
+			values[p][last] = field.Neg(r[i][free])
+		}
+	}
+	d.Field, d.Values = field, values
+	return d
+}
+
+// ColumnSpace sets d to the matrix with columns that are a basis of the column space of a,
+// the pivot columns of a
+// https://en.wikipedia.org/wiki/Row_and_column_spaces
+func (d *Dense[T]) ColumnSpace(a *Dense[T]) *Dense[T] {
+	pivots := rref(a.Field, a.copy())
+	values := make([][]T, len(a.Values))
+	for i := range values {
+		for _, p := range pivots {
+			values[i] = append(values[i], a.set(a.Values[i][p]))
+		}
+	}
+	d.Field, d.Values = a.Field, values
+	return d
+}
+
+// RowSpace sets d to the matrix with rows that are a basis of the row space of a,
+// the non zero rows of the reduced row echelon form of a
+// https://en.wikipedia.org/wiki/Row_and_column_spaces
+func (d *Dense[T]) RowSpace(a *Dense[T]) *Dense[T] {
+	values := a.copy()
+	pivots := rref(a.Field, values)
+	d.Field, d.Values = a.Field, values[:len(pivots)]
+	return d
+}
+
+// RREF sets m to the exact reduced row echelon form of a and returns m and the pivot columns
+func (m *Matrix) RREF(a *Matrix) (*Matrix, []int) {
+	d := a.Dense()
+	_, pivots := d.RREF(&d)
+	return m.SetDense(&d), pivots
+}
+
+// Rank computes the exact rank of m
+func (m *Matrix) Rank() int {
+	d := m.Dense()
+	return d.Rank()
+}
+
+// NullSpace sets m to the matrix with columns that are an exact basis of the null space of a
+func (m *Matrix) NullSpace(a *Matrix) *Matrix {
+	d := a.Dense()
+	return m.SetDense(d.NullSpace(&d))
+}
+
+// ColumnSpace sets m to the matrix with columns that are an exact basis of the column space
+// of a
+func (m *Matrix) ColumnSpace(a *Matrix) *Matrix {
+	d := a.Dense()
+	return m.SetDense(d.ColumnSpace(&d))
+}
+
+// RowSpace sets m to the matrix with rows that are an exact basis of the row space of a
+func (m *Matrix) RowSpace(a *Matrix) *Matrix {
+	d := a.Dense()
+	return m.SetDense(d.RowSpace(&d))
+}
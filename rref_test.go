@@ -0,0 +1,98 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+func TestMatrix_RREF(t *testing.T) {
+	a := rationalMatrix(64, 1,
+		[]int64{1, 0, 2, 0, 0, 1, 0, 0},
+		[]int64{2, 0, 4, 0, 0, 2, 1, 0},
+		[]int64{1, 0, 2, 0, 0, 1, 1, 0},
+	)
+	r := NewMatrix(64)
+	_, pivots := r.RREF(&a)
+	if r.String() != "[1 2 0 + 1i 0;0 0 0 1;0 0 0 0]" || fmt.Sprint(pivots) != "[0 3]" {
+		t.Fatal("invalid reduced row echelon form", r.String(), pivots)
+	}
+	if rank := a.Rank(); rank != 2 {
+		t.Fatal("invalid rank", rank)
+	}
+
+	n := NewMatrix(64)
+	n.NullSpace(&a)
+	if n.String() != "[-2 0 + -1i;1 0;0 1;0 0]" {
+		t.Fatal("invalid null space", n.String())
+	}
+	z := NewMatrix(64)
+	z.Mul(&a, &n)
+	if z.String() != "[0 0;0 0;0 0]" {
+		t.Fatal("invalid null space", z.String())
+	}
+
+	c := NewMatrix(64)
+	c.ColumnSpace(&a)
+	if c.String() != "[1 0;2 1;1 1]" {
+		t.Fatal("invalid column space", c.String())
+	}
+	s := NewMatrix(64)
+	s.RowSpace(&a)
+	if s.String() != "[1 2 0 + 1i 0;0 0 0 1]" {
+		t.Fatal("invalid row space", s.String())
+	}
+
+	// rank + nullity is the number of columns
+	b := rationalMatrix(64, 1,
+		[]int64{2, 1, 0, 0, 1, 1},
+		[]int64{1, 3, 0, 0, 4, -1},
+		[]int64{0, 1, 5, 0, 1, 0},
+	)
+	n.NullSpace(&b)
+	if rank := b.Rank(); rank != 3 || len(n.Values[0]) != 0 {
+		t.Fatal("invalid rank", rank, n.String())
+	}
+	r.RREF(&b)
+	if r.String() != "[1 0 0;0 1 0;0 0 1]" {
+		t.Fatal("invalid reduced row echelon form", r.String())
+	}
+}
+
+func TestDense_RREF(t *testing.T) {
+	// over GF(2) the rows of the parity check matrix of the [7,4] Hamming code
+	field := NewModularField(big.NewInt(2))
+	bits := func(values ...int64) []*big.Int {
+		var row []*big.Int
+		for _, v := range values {
+			row = append(row, big.NewInt(v))
+		}
+		return row
+	}
+	h := Dense[*big.Int]{Field: field, Values: [][]*big.Int{
+		bits(1, 0, 1, 0, 1, 0, 1),
+		bits(0, 1, 1, 0, 0, 1, 1),
+		bits(0, 0, 0, 1, 1, 1, 1),
+	}}
+	if rank := h.Rank(); rank != 3 {
+		t.Fatal("invalid rank", rank)
+	}
+	g := Dense[*big.Int]{}
+	g.NullSpace(&h)
+	if len(g.Values) != 7 || len(g.Values[0]) != 4 {
+		t.Fatal("invalid null space", g.String())
+	}
+	z := Dense[*big.Int]{}
+	z.Mul(&h, &g)
+	for _, row := range z.Values {
+		for _, x := range row {
+			if !field.IsZero(x) {
+				t.Fatal("invalid code word", g.String())
+			}
+		}
+	}
+}
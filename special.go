@@ -0,0 +1,99 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// The special values of Float follow the Riemann sphere: a number with an infinite part
+// is the single unsigned point at infinity, which is stored as +Inf + 0i, and the result of
+// an undefined operation such as 0/0, inf - inf, 0 * inf or a function at its essential
+// singularity is NaN. Every function of a NaN is NaN. A function with a pole is infinite at
+// the pole, and results that overflow the exponent range of big.Float are infinite, or NaN if
+// their value can't be determined. Zeros are signed and the sign of the imaginary part of
+// a number on a branch cut selects the side of the cut as in C99 Annex G, so
+// log(-1 - 0i) = -pi i and sqrt(-4 - 0i) = -2i
+// https://en.wikipedia.org/wiki/Riemann_sphere
+// https://en.wikipedia.org/wiki/C99#IEEE_754_floating-point_support
+
+// limit is the value of a function at the point at infinity
+type limit int
+
+const (
+	// undefined is the value at an essential singularity
+	undefined limit = iota
+	// infinite is the value at a pole
+	infinite
+)
+
+// IsInf determines if f is the point at infinity
+func (f *Float) IsInf() bool {
+	return !f.nan && (f.A.IsInf() || f.B.IsInf())
+}
+
+// IsNaN determines if f is the result of an undefined operation
+func (f *Float) IsNaN() bool {
+	return f.nan
+}
+
+// SetInf sets f to the point at infinity
+func (f *Float) SetInf() *Float {
+	f.nan, f.uncertain = false, false
+	f.A.SetInf(false)
+	f.B.SetInt64(0)
+	return f
+}
+
+// SetNaN sets f to NaN
+func (f *Float) SetNaN() *Float {
+	f.nan, f.uncertain = true, false
+	f.A.SetInt64(0)
+	f.B.SetInt64(0)
+	return f
+}
+
+// isZero determines if f is a signed zero
+func (f *Float) isZero() bool {
+	return !f.nan && f.A.Sign() == 0 && f.B.Sign() == 0
+}
+
+// setLimit sets f to the value l
+func (f *Float) setLimit(l limit) *Float {
+	if l == infinite {
+		return f.SetInf()
+	}
+	return f.SetNaN()
+}
+
+// finite computes f with function for operands that are finite numbers, an ErrNaN
+// from an overflow of the exponent range makes the result NaN
+func (f *Float) finite(function func() *Float) (result *Float) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(big.ErrNaN); !ok {
+				panic(r)
+			}
+			result = f.SetNaN()
+		}
+	}()
+	f.nan, f.uncertain = false, false
+	function()
+	if f.A.IsInf() || f.B.IsInf() {
+		f.SetInf()
+	}
+	return f
+}
+
+// unary computes f with function for x, the value of the function at infinity is l
+func (f *Float) unary(x *Float, l limit, function func() *Float) *Float {
+	if x.nan {
+		return f.SetNaN()
+	}
+	if x.IsInf() {
+		return f.setLimit(l)
+	}
+	return f.finite(function)
+}
@@ -0,0 +1,152 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+)
+
+// special creates the number a + bi, where the parts can be infinite or negative zero
+func special(a, b float64, negative ...bool) *Float {
+	x := NewFloat(big.NewFloat(a).SetPrec(64), big.NewFloat(b).SetPrec(64))
+	if len(negative) > 0 && negative[0] {
+		x.A.Neg(x.A)
+	}
+	if len(negative) > 1 && negative[1] {
+		x.B.Neg(x.B)
+	}
+	return x
+}
+
+func TestFloat_Inf(t *testing.T) {
+	inf, zero, one := newFloat(64).SetInf(), special(0, 0), special(1, 1)
+	nan := newFloat(64).SetNaN()
+	tests := []struct {
+		name   string
+		result *Float
+		want   string
+	}{
+		{"inf + 1", newFloat(64).Add(inf, one), "+Inf"},
+		{"inf - inf", newFloat(64).Sub(inf, inf), "NaN"},
+		{"inf * 1", newFloat(64).Mul(inf, one), "+Inf"},
+		{"inf * 0", newFloat(64).Mul(inf, zero), "NaN"},
+		{"1 / 0", newFloat(64).Div(one, zero), "+Inf"},
+		{"0 / 0", newFloat(64).Div(zero, zero), "NaN"},
+		{"1 / inf", newFloat(64).Div(one, inf), "0"},
+		{"inf / inf", newFloat(64).Div(inf, inf), "NaN"},
+		{"nan + 1", newFloat(64).Add(nan, one), "NaN"},
+		{"conj(-i / 0)", newFloat(64).Conj(newFloat(64).Div(special(0, -1), zero)), "+Inf"},
+		{"log(0)", newFloat(64).Log(zero), "+Inf"},
+		{"log(inf)", newFloat(64).Log(inf), "+Inf"},
+		{"log1p(-1)", newFloat(64).Log1p(special(-1, 0)), "+Inf"},
+		{"logbase(2, 1)", newFloat(64).LogBase(special(2, 0), special(1, 0)), "+Inf"},
+		{"logbase(1, 1)", newFloat(64).LogBase(special(1, 0), special(1, 0)), "NaN"},
+		{"logbase(2, inf)", newFloat(64).LogBase(special(2, 0), inf), "0"},
+		{"exp(inf)", newFloat(64).Exp(inf), "NaN"},
+		{"sin(inf)", newFloat(64).Sin(inf), "NaN"},
+		{"sqrt(inf)", newFloat(64).Sqrt(inf), "+Inf"},
+		{"abs(inf)", newFloat(64).Abs(special(1, 0).SetInf()), "+Inf"},
+		{"pow(0, 0)", newFloat(64).Pow(zero, zero), "1"},
+		{"pow(0, 1)", newFloat(64).Pow(zero, one), "0"},
+		{"pow(0, -1)", newFloat(64).Pow(zero, special(-1, 0)), "+Inf"},
+		{"pow(0, i)", newFloat(64).Pow(zero, special(0, 1)), "NaN"},
+		{"pow(inf, -1)", newFloat(64).Pow(inf, special(-1, 0)), "0"},
+		{"pow(2, inf)", newFloat(64).Pow(special(2, 0), inf), "NaN"},
+		{"lambertw(0)", newFloat(64).LambertW(-1, zero), "+Inf"},
+		{"besselj(-1/2, 0)", newFloat(64).BesselJ(big.NewFloat(-.5), zero), "+Inf"},
+		{"besselj(0, 0)", newFloat(64).BesselJ(big.NewFloat(0), zero), "1"},
+		{"besselj(-2, 0)", newFloat(64).BesselJ(big.NewFloat(-2), zero), "0"},
+		{"bessely(-1/2, 0)", newFloat(64).BesselY(big.NewFloat(-.5), zero), "0"},
+		{"bessely(1/2, 0)", newFloat(64).BesselY(big.NewFloat(.5), zero), "+Inf"},
+		{"besselk(0, 0)", newFloat(64).BesselK(big.NewFloat(0), zero), "+Inf"},
+		{"exp(3e9)", newFloat(64).Exp(special(3e9, 0)), "+Inf"},
+		{"cos(3e9i)", newFloat(64).Cos(special(0, 3e9)), "+Inf"},
+		{"tan(1 + 3e9i)", newFloat(64).Tan(special(1, 3e9)), "0 + 1i"},
+	}
+	for _, test := range tests {
+		if s := test.result.String(); s != test.want {
+			t.Fatal("invalid result", test.name, s, test.want)
+		}
+		if test.result.IsInf() && (test.result.A.Cmp(inf.A) != 0 || test.result.B.Sign() != 0) {
+			t.Fatal("invalid infinity", test.name, test.result.A, test.result.B)
+		}
+	}
+	if inf.Modulus().String() != "+Inf" {
+		t.Fatal("invalid modulus")
+	}
+	if inf.Phase() != nil {
+		t.Fatal("invalid phase")
+	}
+	if r, theta := nan.Polar(); r != nil || theta != nil {
+		t.Fatal("invalid polar form", r, theta)
+	}
+}
+
+func TestFloat_SignedZero(t *testing.T) {
+	tests := []struct {
+		name   string
+		result *Float
+		want   string
+	}{
+		{"log(-1 + 0i)", newFloat(64).Log(special(-1, 0)), "0 + 3.141592654i"},
+		{"log(-1 - 0i)", newFloat(64).Log(special(-1, 0, false, true)), "0 + -3.141592654i"},
+		{"sqrt(-4 + 0i)", newFloat(64).Sqrt(special(-4, 0)), "0 + 2i"},
+		{"sqrt(-4 - 0i)", newFloat(64).Sqrt(special(-4, 0, false, true)), "0 + -2i"},
+		{"atan2(0 + 0i)", newFloat(64).Atan2(special(0, 0)), "0"},
+		{"atan2(-0 + 0i)", newFloat(64).Atan2(special(0, 0, true)), "3.141592654"},
+		{"atan2(-0 - 0i)", newFloat(64).Atan2(special(0, 0, true, true)), "-3.141592654"},
+		{"arg(0)", newFloat(64).Arg(special(0, 0)), "0"},
+		{"log1p(-2 - 0i)", newFloat(64).Log1p(special(-2, 0, false, true)), "0 + -3.141592654i"},
+		{"pow(-8 - 0i, 1/3)", newFloat(64).Pow(special(-8, 0, false, true), special(1.0/3, 0)), "1 + -1.732050808i"},
+	}
+	for _, test := range tests {
+		if s := test.result.String(); s != test.want {
+			t.Fatal("invalid result", test.name, s, test.want)
+		}
+	}
+}
+
+// TestFloat_NaN checks that every function of NaN is NaN and that no function panics
+// for the special values
+func TestFloat_NaN(t *testing.T) {
+	typ := reflect.TypeOf(&Float{})
+	values := []*Float{
+		special(0, 0), special(0, 0, true, true), special(-1, 0, false, true),
+		newFloat(64).SetInf(), special(1, 0).SetInf(), newFloat(64).SetNaN(),
+		special(3e9, 0), special(-3e9, 1), special(1, 3e9), special(0, -3e9),
+	}
+	for m := 0; m < typ.NumMethod(); m++ {
+		method := typ.Method(m)
+		if method.Type.NumOut() != 1 || method.Type.Out(0) != typ || method.Type.NumIn() < 2 {
+			continue
+		}
+		for _, value := range values {
+			var args []reflect.Value
+			for i := 1; i < method.Type.NumIn(); i++ {
+				switch method.Type.In(i) {
+				case typ:
+					args = append(args, reflect.ValueOf(value))
+				case reflect.TypeOf(0):
+					args = append(args, reflect.ValueOf(-1))
+				case reflect.TypeOf(&big.Float{}):
+					args = append(args, reflect.ValueOf(big.NewFloat(.5)))
+				}
+			}
+			func() {
+				defer func() {
+					if r := recover(); r != nil {
+						t.Fatal("panic", method.Name, value.String(), r)
+					}
+				}()
+				result := method.Func.Call(append([]reflect.Value{reflect.ValueOf(newFloat(64))}, args...))[0].Interface().(*Float)
+				if value.IsNaN() && !result.IsNaN() {
+					t.Fatal("invalid result", method.Name, result.String())
+				}
+			}()
+		}
+	}
+}
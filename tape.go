@@ -0,0 +1,286 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+)
+
+// Tape records Matrix operations for reverse mode automatic differentiation. The forward pass
+// is computed by the Matrix methods, and Backward propagates the Wirtinger gradient
+// dL/dconj(z) = (dL/dx + i dL/dy)/2 of a real valued loss L to every node z = x + iy.
+// For a holomorphic w = f(z) the gradient of z is conj(f'(z)) times the gradient of w, so
+// -2 dL/dconj(z) is the direction of steepest descent. The gradients are rounded to the
+// precision and mode of the context
+// https://en.wikipedia.org/wiki/Automatic_differentiation#Reverse_accumulation
+// https://en.wikipedia.org/wiki/Wirtinger_derivatives
+type Tape struct {
+	Context *Context
+	nodes   []*Node
+}
+
+// Node is a matrix recorded on a tape, Grad is the gradient of the loss after Backward
+type Node struct {
+	Value    Matrix
+	Grad     Matrix
+	backward func()
+}
+
+// NewTape creates a new tape with the given precision and rounding mode
+func NewTape(prec uint, mode big.RoundingMode) *Tape {
+	return &Tape{
+		Context: NewContext(prec, mode),
+	}
+}
+
+// Reset removes the nodes from the tape
+func (t *Tape) Reset() {
+	t.nodes = nil
+}
+
+// node records the value on the tape
+func (t *Tape) node(value *Matrix) *Node {
+	n := &Node{
+		Value: *value,
+	}
+	t.nodes = append(t.nodes, n)
+	return n
+}
+
+// matrix creates an empty matrix with the context of the tape
+func (t *Tape) matrix() *Matrix {
+	m := t.Context.NewMatrix()
+	return &m
+}
+
+// entrywise applies the function to the entries of a and b, a 1x1 matrix is applied to every
+// entry of the other matrix
+func (t *Tape) entrywise(a, b *Matrix, function func(x, y *Rational) *Rational) *Matrix {
+	rows := a
+	if a.singular() {
+		rows = b
+	}
+	entry := func(x *Matrix, i, j int) *Rational {
+		if x.singular() {
+			return &x.Values[0][0]
+		}
+		return &x.Values[i][j]
+	}
+	m := t.matrix()
+	for i := range rows.Values {
+		var row []Rational
+		for j := range rows.Values[i] {
+			row = append(row, *function(entry(a, i, j), entry(b, i, j)))
+		}
+		m.Values = append(m.Values, row)
+	}
+	return m
+}
+
+// round rounds the entries of m to the context of the tape
+func (t *Tape) round(m *Matrix) *Matrix {
+	x := t.Context.NewFloat(nil, nil)
+	return t.entrywise(m, m, func(a, _ *Rational) *Rational {
+		r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+		x.SetRat(a)
+		x.Rat(r)
+		return r
+	})
+}
+
+// adjoint computes the conjugate transpose of a
+func (t *Tape) adjoint(a *Matrix) *Matrix {
+	m := t.matrix()
+	for j := range a.Values[0] {
+		var row []Rational
+		for i := range a.Values {
+			row = append(row, *NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Conj(&a.Values[i][j]))
+		}
+		m.Values = append(m.Values, row)
+	}
+	return m
+}
+
+// accumulate adds the gradient g to n, the entries of g are summed for a 1x1 matrix n that
+// was applied to every entry of a larger matrix
+func (t *Tape) accumulate(n *Node, g *Matrix) {
+	if n.Value.singular() && !g.singular() {
+		sum := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+		for i := range g.Values {
+			for j := range g.Values[i] {
+				sum.Add(sum, &g.Values[i][j])
+			}
+		}
+		g = t.matrix()
+		g.Values = [][]Rational{{*sum}}
+	}
+	n.Grad = *t.round(t.entrywise(&n.Grad, g, func(x, y *Rational) *Rational {
+		return NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Add(x, y)
+	}))
+}
+
+// holomorphic accumulates conj(f'(z)) times the gradient of w to the gradient of z
+func (t *Tape) holomorphic(z, w *Node, derivative *Matrix) {
+	t.accumulate(z, t.entrywise(&w.Grad, derivative, func(x, y *Rational) *Rational {
+		c := NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Conj(y)
+		return c.Mul(x, c)
+	}))
+}
+
+// Variable records the matrix m as an input of the tape
+func (t *Tape) Variable(m Matrix) *Node {
+	return t.node(&m)
+}
+
+// Add adds two nodes
+func (t *Tape) Add(a, b *Node) *Node {
+	w := t.node(t.matrix().Add(&a.Value, &b.Value))
+	w.backward = func() {
+		t.accumulate(a, &w.Grad)
+		t.accumulate(b, &w.Grad)
+	}
+	return w
+}
+
+// Sub subtracts two nodes
+func (t *Tape) Sub(a, b *Node) *Node {
+	w := t.node(t.matrix().Sub(&a.Value, &b.Value))
+	w.backward = func() {
+		t.accumulate(a, &w.Grad)
+		t.accumulate(b, t.matrix().Neg(&w.Grad))
+	}
+	return w
+}
+
+// Mul multiplies two nodes, the gradients of a and b for the matrix product w = ab are g b^H
+// and a^H g for the gradient g of w
+func (t *Tape) Mul(a, b *Node) *Node {
+	w := t.node(t.matrix().Mul(&a.Value, &b.Value))
+	w.backward = func() {
+		if a.Value.singular() || b.Value.singular() {
+			t.holomorphic(a, w, &b.Value)
+			t.holomorphic(b, w, &a.Value)
+			return
+		}
+		t.accumulate(a, t.matrix().Mul(&w.Grad, t.adjoint(&b.Value)))
+		t.accumulate(b, t.matrix().Mul(t.adjoint(&a.Value), &w.Grad))
+	}
+	return w
+}
+
+// Exp computes e^z for the entries of the node, with exp'(z) = e^z
+func (t *Tape) Exp(z *Node) *Node {
+	w := t.node(t.matrix().Exp(&z.Value))
+	w.backward = func() {
+		t.holomorphic(z, w, &w.Value)
+	}
+	return w
+}
+
+// Log computes the natural log of the entries of the node, with log'(z) = 1/z
+func (t *Tape) Log(z *Node) *Node {
+	w := t.node(t.matrix().Log(&z.Value))
+	w.backward = func() {
+		one := NewRational(big.NewRat(1, 1), big.NewRat(0, 1))
+		t.holomorphic(z, w, t.entrywise(&z.Value, &z.Value, func(x, _ *Rational) *Rational {
+			return NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Div(one, x)
+		}))
+	}
+	return w
+}
+
+// Sin computes the sine of the entries of the node, with sin'(z) = cos(z)
+func (t *Tape) Sin(z *Node) *Node {
+	w := t.node(t.matrix().Sin(&z.Value))
+	w.backward = func() {
+		t.holomorphic(z, w, t.matrix().Cos(&z.Value))
+	}
+	return w
+}
+
+// Cos computes the cosine of the entries of the node, with cos'(z) = -sin(z)
+func (t *Tape) Cos(z *Node) *Node {
+	w := t.node(t.matrix().Cos(&z.Value))
+	w.backward = func() {
+		derivative := t.matrix().Sin(&z.Value)
+		t.holomorphic(z, w, derivative.Neg(derivative))
+	}
+	return w
+}
+
+// Pow computes z**y for the entries of the node and the constant y, with the derivative
+// y z**(y-1)
+func (t *Tape) Pow(z *Node, y *Rational) *Node {
+	w := t.node(t.matrix().Pow(&z.Value, y))
+	w.backward = func() {
+		one := NewRational(big.NewRat(1, 1), big.NewRat(0, 1))
+		derivative := t.matrix().Pow(&z.Value, NewRational(big.NewRat(0, 1), big.NewRat(0, 1)).Sub(y, one))
+		scale := t.matrix()
+		scale.Values = [][]Rational{{*y.copy()}}
+		t.holomorphic(z, w, derivative.Mul(scale, derivative))
+	}
+	return w
+}
+
+// Conj computes the complex conjugate of the node, the gradient of z is the conjugate of
+// the gradient of w = conj(z)
+func (t *Tape) Conj(z *Node) *Node {
+	w := t.node(t.matrix().Conj(&z.Value))
+	w.backward = func() {
+		t.accumulate(z, t.matrix().Conj(&w.Grad))
+	}
+	return w
+}
+
+// Abs computes the absolute value of the entries of the node, the gradient of z is
+// Re(g) z/|z| for the gradient g of w = |z| and 0 for z = 0
+func (t *Tape) Abs(z *Node) *Node {
+	w := t.node(t.matrix().Abs(&z.Value))
+	w.backward = func() {
+		direction := t.entrywise(&z.Value, &w.Value, func(x, y *Rational) *Rational {
+			r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+			if y.A.Sign() == 0 {
+				return r
+			}
+			r.A.Quo(x.A, y.A)
+			r.B.Quo(x.B, y.A)
+			return r
+		})
+		t.accumulate(z, t.entrywise(&w.Grad, direction, func(x, y *Rational) *Rational {
+			r := NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+			r.A.Mul(x.A, y.A)
+			r.B.Mul(x.A, y.B)
+			return r
+		}))
+	}
+	return w
+}
+
+// Backward computes the gradients of the loss L = Re(l) for the 1x1 node l with respect to
+// the nodes recorded on the tape before l
+func (t *Tape) Backward(l *Node) {
+	if !l.Value.singular() {
+		panic("the loss isn't a 1x1 matrix")
+	}
+	last := len(t.nodes) - 1
+	for last >= 0 && t.nodes[last] != l {
+		last--
+	}
+	if last < 0 {
+		panic("the loss isn't on the tape")
+	}
+	for _, n := range t.nodes {
+		n.Grad = *t.entrywise(&n.Value, &n.Value, func(_, _ *Rational) *Rational {
+			return NewRational(big.NewRat(0, 1), big.NewRat(0, 1))
+		})
+	}
+	// dRe(l)/dconj(l) = 1/2
+	l.Grad.Values[0][0].A.SetFrac64(1, 2)
+	for i := last; i >= 0; i-- {
+		if n := t.nodes[i]; n.backward != nil {
+			n.backward()
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Copyright 2020 The C0mpl3x Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+package big
+
+import (
+	"math/big"
+	"testing"
+)
+
+// tapeModel records a model using every operation of the tape and returns the loss
+func tapeModel(t *Tape, z, s Matrix) (*Node, *Node, *Node) {
+	x, y := t.Variable(z), t.Variable(s)
+	w := t.Variable(rationalMatrix(t.Context.Prec, 8, []int64{2, 1, -3, 0}, []int64{1, -1, 4, 2}))
+	a := t.Mul(w, x)
+	b := t.Add(t.Exp(a), t.Sin(x))
+	c := t.Sub(t.Cos(t.Conj(x)), t.Pow(t.Log(b), NewRational(big.NewRat(3, 2), big.NewRat(0, 1))))
+	d := t.Add(t.Mul(y, t.Conj(c)), t.Abs(c))
+	u := t.Variable(rationalMatrix(t.Context.Prec, 8, []int64{8, 0, 4, 4}))
+	v := t.Variable(rationalMatrix(t.Context.Prec, 8, []int64{8, 0}, []int64{-4, 8}))
+	return t.Mul(t.Mul(u, d), v), x, y
+}
+
+func TestTape(t *testing.T) {
+	tape := NewTape(64, big.ToNearestEven)
+	z := tape.Variable(rationalMatrix(64, 8, []int64{8, 16}))
+	l := tape.Pow(z, NewRational(big.NewRat(2, 1), big.NewRat(0, 1)))
+	tape.Backward(l)
+	// d Re(z^2)/dconj(z) = conj(z)
+	if z.Grad.String() != "1 + -2i" {
+		t.Fatal("invalid gradient", z.Grad.String())
+	}
+	l = tape.Abs(z)
+	tape.Backward(l)
+	// d|z|/dconj(z) = z/(2|z|)
+	if z.Grad.String() != "0.2236067977 + 0.4472135955i" {
+		t.Fatal("invalid gradient", z.Grad.String())
+	}
+
+	tape.Reset()
+	defer func() {
+		if recover() == nil {
+			t.Fatal("loss not on the tape")
+		}
+	}()
+	tape.Backward(l)
+}
+
+func TestTape_FiniteDifferences(t *testing.T) {
+	const prec = 256
+	z := rationalMatrix(prec, 8, []int64{3, 1, -2, 2}, []int64{1, -4, 5, 1})
+	s := rationalMatrix(prec, 8, []int64{2, -3})
+	tape := NewTape(prec, big.ToNearestEven)
+	l, x, y := tapeModel(tape, z, s)
+	tape.Backward(l)
+
+	// loss computes Re(l) for the perturbed entry
+	loss := func(z, s Matrix) *big.Float {
+		l, _, _ := tapeModel(NewTape(prec, big.ToNearestEven), z, s)
+		return big.NewFloat(0).SetPrec(prec).SetRat(l.Value.Values[0][0].A)
+	}
+	h := big.NewRat(1, 1<<60)
+	check := func(name string, variable *Matrix, grad *Matrix) {
+		for i := range variable.Values {
+			for j := range variable.Values[i] {
+				entry := &variable.Values[i][j]
+				// (dL/dx + i dL/dy)/2 with central differences
+				derivative := func(part *big.Rat) *big.Float {
+					saved := big.NewRat(0, 1).Set(part)
+					part.Add(saved, h)
+					plus := loss(z, s)
+					part.Sub(saved, h)
+					minus := loss(z, s)
+					part.Set(saved)
+					plus.Sub(plus, minus)
+					return plus.Quo(plus, big.NewFloat(0).SetRat(h)).Quo(plus, big.NewFloat(4))
+				}
+				expected := NewFloat(derivative(entry.A), derivative(entry.B))
+				actual := newFloat(prec)
+				actual.SetRat(&grad.Values[i][j])
+				e := newFloat(prec).Sub(actual, expected)
+				if norm := newFloat(prec).Abs(e); norm.A.Cmp(big.NewFloat(0x1p-100)) > 0 {
+					t.Fatal("invalid gradient", name, i, j, actual.String(), expected.String())
+				}
+			}
+		}
+	}
+	check("z", &z, &x.Grad)
+	check("s", &s, &y.Grad)
+}